@@ -0,0 +1,4 @@
+# List the snapshots of a bucket
+data "tigris_bucket_snapshots" "example_snapshots" {
+  bucket = "my-custom-bucket"
+}
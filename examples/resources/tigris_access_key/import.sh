@@ -0,0 +1,3 @@
+# The access key can be imported using its ID. The secret is only returned when
+# the key is created, so secret_access_key is not set for imported keys.
+terraform import tigris_access_key.example_key tid_AaBbCcDdEeFfGgHhIiJjKkLlMmNnOoPp
@@ -0,0 +1,12 @@
+resource "tigris_access_key" "example_key" {
+  name = "my-application"
+}
+
+output "access_key_id" {
+  value = tigris_access_key.example_key.access_key_id
+}
+
+output "secret_access_key" {
+  value     = tigris_access_key.example_key.secret_access_key
+  sensitive = true
+}
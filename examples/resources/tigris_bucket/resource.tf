@@ -1,3 +1,7 @@
 resource "tigris_bucket" "example_bucket" {
   bucket = "my-custom-bucket"
+
+  tags = {
+    environment = "production"
+  }
 }
\ No newline at end of file
@@ -0,0 +1,2 @@
+# The CORS configuration can be imported using the bucket name
+terraform import tigris_bucket_cors_configuration.example_cors my-custom-bucket
@@ -0,0 +1,21 @@
+resource "tigris_bucket" "example_bucket" {
+  bucket = "my-custom-bucket"
+}
+
+# Allow browsers to upload directly to the bucket
+resource "tigris_bucket_cors_configuration" "example_cors" {
+  bucket = tigris_bucket.example_bucket.bucket
+
+  cors_rule {
+    allowed_methods = ["GET", "PUT", "POST"]
+    allowed_origins = ["https://example.com"]
+    allowed_headers = ["*"]
+    expose_headers  = ["ETag"]
+    max_age_seconds = 3600
+  }
+
+  cors_rule {
+    allowed_methods = ["GET"]
+    allowed_origins = ["*"]
+  }
+}
@@ -0,0 +1,2 @@
+# The lifecycle configuration can be imported using the bucket name
+terraform import tigris_bucket_lifecycle_configuration.example_lifecycle my-custom-bucket
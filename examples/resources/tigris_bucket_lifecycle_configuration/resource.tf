@@ -0,0 +1,39 @@
+resource "tigris_bucket" "example_bucket" {
+  bucket = "my-custom-bucket"
+}
+
+resource "tigris_bucket_lifecycle_configuration" "example_lifecycle" {
+  bucket = tigris_bucket.example_bucket.bucket
+
+  # Move scratch objects to cheaper storage, then expire them
+  rule {
+    id = "scratch"
+
+    filter {
+      prefix = "scratch/"
+    }
+
+    transition {
+      days          = 7
+      storage_class = "STANDARD_IA"
+    }
+
+    expiration {
+      days = 30
+    }
+  }
+
+  # Clean up old versions and abandoned uploads everywhere in the bucket
+  rule {
+    id = "cleanup"
+
+    noncurrent_version_expiration {
+      noncurrent_days           = 30
+      newer_noncurrent_versions = 3
+    }
+
+    abort_incomplete_multipart_upload {
+      days_after_initiation = 7
+    }
+  }
+}
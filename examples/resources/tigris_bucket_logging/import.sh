@@ -0,0 +1,2 @@
+# The logging configuration can be imported using the bucket name
+terraform import tigris_bucket_logging.example_logging my-custom-bucket
@@ -0,0 +1,14 @@
+resource "tigris_bucket" "example_bucket" {
+  bucket = "my-custom-bucket"
+}
+
+resource "tigris_bucket" "example_log_bucket" {
+  bucket = "my-access-logs-bucket"
+}
+
+# Deliver the server access logs of the bucket into the log bucket
+resource "tigris_bucket_logging" "example_logging" {
+  bucket        = tigris_bucket.example_bucket.bucket
+  target_bucket = tigris_bucket.example_log_bucket.bucket
+  target_prefix = "my-custom-bucket/"
+}
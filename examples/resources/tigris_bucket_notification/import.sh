@@ -0,0 +1,3 @@
+# The notification can be imported using the bucket name. The auth token or
+# password is not imported and must be set in the configuration.
+terraform import tigris_bucket_notification.example_notification my-custom-bucket
@@ -0,0 +1,18 @@
+resource "tigris_bucket" "example_bucket" {
+  bucket = "my-custom-bucket"
+}
+
+variable "webhook_token" {
+  type      = string
+  sensitive = true
+}
+
+# POST the events of new and deleted images to a webhook
+resource "tigris_bucket_notification" "example_notification" {
+  bucket       = tigris_bucket.example_bucket.bucket
+  webhook_url  = "https://example.com/webhooks/tigris"
+  events       = ["OBJECT_CREATED_PUT", "OBJECT_DELETED"]
+  prefix       = "images/"
+  auth_token   = var.webhook_token
+  auth_version = 1
+}
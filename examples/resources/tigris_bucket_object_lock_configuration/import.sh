@@ -0,0 +1,2 @@
+# The object lock configuration can be imported using the bucket name
+terraform import tigris_bucket_object_lock_configuration.example_object_lock my-audit-logs
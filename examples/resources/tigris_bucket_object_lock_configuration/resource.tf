@@ -0,0 +1,15 @@
+# Object lock can only be enabled when the bucket is created
+resource "tigris_bucket" "example_bucket" {
+  bucket              = "my-audit-logs"
+  object_lock_enabled = true
+}
+
+# Retain new objects for a year
+resource "tigris_bucket_object_lock_configuration" "example_object_lock" {
+  bucket = tigris_bucket.example_bucket.bucket
+
+  default_retention {
+    mode  = "COMPLIANCE"
+    years = 1
+  }
+}
@@ -0,0 +1,2 @@
+# The policy can be imported using the bucket name
+terraform import tigris_bucket_policy.example_policy my-custom-bucket
@@ -0,0 +1,20 @@
+resource "tigris_bucket" "example_bucket" {
+  bucket = "my-custom-bucket"
+}
+
+# Allow anyone to read the objects under public/
+resource "tigris_bucket_policy" "example_policy" {
+  bucket = tigris_bucket.example_bucket.bucket
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "PublicRead"
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:GetObject"
+        Resource  = "arn:aws:s3:::my-custom-bucket/public/*"
+      }
+    ]
+  })
+}
@@ -0,0 +1,2 @@
+# The server-side encryption configuration can be imported using the bucket name
+terraform import tigris_bucket_server_side_encryption.example_encryption my-custom-bucket
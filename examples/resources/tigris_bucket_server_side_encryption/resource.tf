@@ -0,0 +1,9 @@
+resource "tigris_bucket" "example_bucket" {
+  bucket = "my-custom-bucket"
+}
+
+# Encrypt new objects in the bucket by default
+resource "tigris_bucket_server_side_encryption" "example_encryption" {
+  bucket        = tigris_bucket.example_bucket.bucket
+  sse_algorithm = "AES256"
+}
@@ -0,0 +1,11 @@
+# Create a bucket with snapshots enabled
+resource "tigris_bucket" "example_bucket" {
+  bucket          = "my-custom-bucket"
+  enable_snapshot = true
+}
+
+# Take a named snapshot of the bucket
+resource "tigris_bucket_snapshot" "example_snapshot" {
+  bucket = tigris_bucket.example_bucket.bucket
+  name   = "before-migration"
+}
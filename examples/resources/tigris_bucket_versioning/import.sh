@@ -0,0 +1,2 @@
+# The versioning configuration can be imported using the bucket name
+terraform import tigris_bucket_versioning.example_versioning my-custom-bucket
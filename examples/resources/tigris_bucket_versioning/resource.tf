@@ -0,0 +1,9 @@
+resource "tigris_bucket" "example_bucket" {
+  bucket = "my-custom-bucket"
+}
+
+# Keep previous versions of overwritten and deleted objects
+resource "tigris_bucket_versioning" "example_versioning" {
+  bucket = tigris_bucket.example_bucket.bucket
+  status = "Enabled"
+}
@@ -6,20 +6,28 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	shttp "github.com/aws/smithy-go/transport/http"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
 )
 
@@ -27,6 +35,16 @@ const (
 	// DefaultEndpoint is the default endpoint for Tigris object storage service.
 	DefaultEndpoint = "https://fly.storage.tigris.dev"
 
+	// DefaultIAMEndpoint is the default endpoint for the Tigris IAM service.
+	DefaultIAMEndpoint = "https://fly.iam.storage.tigris.dev"
+
+	// iamAPIVersion is the version of the IAM query API.
+	iamAPIVersion = "2010-05-08"
+
+	// MemoryEndpoint selects the in-memory backend instead of the Tigris
+	// object storage service.
+	MemoryEndpoint = "memory://"
+
 	// DefaultRegion is the default region for Tigris object storage service.
 	DefaultRegion = "auto"
 
@@ -37,6 +55,20 @@ const (
 	HeaderAmzIdentityId        = "S3-Identity-Id"
 	HeaderAmzAcl               = "X-Amz-Acl"
 	HeaderAmzPublicListObjects = "X-Amz-Acl-Public-List-Objects-Enabled"
+	HeaderAmzStorageClass      = "X-Amz-Storage-Class"
+	HeaderTigrisEnableSnapshot = "X-Tigris-Enable-Snapshot"
+	HeaderTigrisSnapshot       = "X-Tigris-Snapshot"
+	HeaderTigrisSnapshotVer    = "X-Tigris-Snapshot-Version"
+	HeaderTigrisForkSource     = "X-Tigris-Fork-Source-Bucket"
+	HeaderTigrisForkSnapshot   = "X-Tigris-Fork-Source-Bucket-Snapshot"
+	HeaderTigrisConsistent     = "X-Tigris-Consistent"
+
+	// deleteObjectsBatchSize is the maximum number of objects in a single
+	// DeleteObjects request.
+	deleteObjectsBatchSize = 1000
+
+	// emptyBucketWorkers is the number of batches deleted in parallel.
+	emptyBucketWorkers = 8
 )
 
 type Client struct {
@@ -44,14 +76,99 @@ type Client struct {
 	signer      *v4.Signer
 	credentials aws.Credentials
 	endpoint    string
+	endpointURL string
+	iamEndpoint string
+	region      string
 	httpClient  *http.Client
 	s3Client    *s3.Client
+
+	// Backoff applied between retries of failed requests.
+	backoffDelay    time.Duration
+	maxBackoffDelay time.Duration
+
+	// Whether buckets that already exist are adopted on create by default.
+	adoptExisting bool
+}
+
+// ClientOption configures optional behavior of the Client.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	transport       http.RoundTripper
+	backoffDelay    time.Duration
+	maxBackoffDelay time.Duration
+	adoptExisting   bool
+	iamEndpoint     string
+	endpointURL     string
+	region          string
+}
+
+// WithTransport sets the http.RoundTripper used by both the metadata API
+// requests and the S3 SDK. It is used to record and replay HTTP interactions.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// WithRetryBackoff sets the initial and maximum delay between retries.
+func WithRetryBackoff(delay, maxDelay time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.backoffDelay = delay
+		o.maxBackoffDelay = maxDelay
+	}
+}
+
+// WithAdoptExisting sets whether resources adopt buckets the caller already
+// owns instead of failing to create them, unless they override it.
+func WithAdoptExisting(adopt bool) ClientOption {
+	return func(o *clientOptions) {
+		o.adoptExisting = adopt
+	}
 }
 
-func NewClient(endpoint, accessKeyID, secretAccessKey string) (*Client, error) {
+// WithIAMEndpoint sets the endpoint of the IAM service that manages access
+// keys.
+func WithIAMEndpoint(endpoint string) ClientOption {
+	return func(o *clientOptions) {
+		o.iamEndpoint = endpoint
+	}
+}
+
+// WithEndpointURL sets the endpoint reported to S3 clients, when the requests
+// are sent to another one such as the in-memory backend.
+func WithEndpointURL(endpointURL string) ClientOption {
+	return func(o *clientOptions) {
+		o.endpointURL = endpointURL
+	}
+}
+
+// WithRegion sets the region the requests are signed for, which is also
+// reported to S3 clients.
+func WithRegion(region string) ClientOption {
+	return func(o *clientOptions) {
+		o.region = region
+	}
+}
+
+func NewClient(endpoint, accessKeyID, secretAccessKey string, opts ...ClientOption) (*Client, error) {
+	options := clientOptions{
+		transport:       http.DefaultTransport,
+		backoffDelay:    3 * time.Second,
+		maxBackoffDelay: 60 * time.Second,
+		iamEndpoint:     DefaultIAMEndpoint,
+		endpointURL:     endpoint,
+		region:          DefaultRegion,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	httpClient := &http.Client{Transport: options.transport}
+
 	// Load AWS configuration
 	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithRegion(DefaultRegion),
+		config.WithRegion(options.region),
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, "")),
 	)
 	if err != nil {
@@ -64,7 +181,8 @@ func NewClient(endpoint, accessKeyID, secretAccessKey string) (*Client, error) {
 	// Create S3 service client
 	svc := s3.NewFromConfig(cfg, func(o *s3.Options) {
 		o.BaseEndpoint = aws.String(endpoint)
-		o.Region = DefaultRegion
+		o.Region = options.region
+		o.HTTPClient = httpClient
 	})
 
 	return &Client{
@@ -74,20 +192,68 @@ func NewClient(endpoint, accessKeyID, secretAccessKey string) (*Client, error) {
 			AccessKeyID:     accessKeyID,
 			SecretAccessKey: secretAccessKey,
 		},
-		endpoint:   endpoint,
-		httpClient: &http.Client{},
-		s3Client:   svc,
+		endpoint:        endpoint,
+		endpointURL:     options.endpointURL,
+		iamEndpoint:     options.iamEndpoint,
+		region:          options.region,
+		httpClient:      httpClient,
+		s3Client:        svc,
+		backoffDelay:    options.backoffDelay,
+		maxBackoffDelay: options.maxBackoffDelay,
+		adoptExisting:   options.adoptExisting,
 	}, nil
 }
 
+// AdoptExisting reports whether buckets the caller already owns are adopted
+// on create by default.
+func (c *Client) AdoptExisting() bool {
+	return c.adoptExisting
+}
+
+// isBucketAlreadyOwnedByYou reports whether the error means the bucket
+// already exists and is owned by the caller.
+func isBucketAlreadyOwnedByYou(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "BucketAlreadyOwnedByYou"
+}
+
+// isBucketAlreadyExists reports whether the error means the bucket name is
+// already taken by another account.
+func isBucketAlreadyExists(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "BucketAlreadyExists"
+}
+
 func (c *Client) CreateBucket(ctx context.Context, input *types.BucketUpdateInput) error {
 	if err := validateBucketRequest(input); err != nil {
 		return err
 	}
 
+	var optFns []func(*s3.Options)
+
+	// Set the default storage class if it's provided
+	if input.StorageClass != nil {
+		optFns = append(optFns, withHeader(HeaderAmzStorageClass, string(*input.StorageClass)))
+	}
+
+	// Enable snapshots if it's requested
+	if input.EnableSnapshot != nil && *input.EnableSnapshot {
+		optFns = append(optFns, withHeader(HeaderTigrisEnableSnapshot, "true"))
+	}
+
+	// Fork the bucket from the source bucket if it's provided
+	if input.ForkSourceBucket != nil {
+		optFns = append(optFns, withHeader(HeaderTigrisForkSource, *input.ForkSourceBucket))
+
+		if input.ForkSourceSnapshot != nil {
+			optFns = append(optFns, withHeader(HeaderTigrisForkSnapshot, *input.ForkSourceSnapshot))
+		}
+	}
+
 	_, err := c.s3Client.CreateBucket(ctx, &s3.CreateBucketInput{
-		Bucket: aws.String(input.Bucket),
-	})
+		Bucket:                     aws.String(input.Bucket),
+		ObjectLockEnabledForBucket: input.ObjectLockEnabled,
+	}, optFns...)
 
 	return err
 }
@@ -110,6 +276,31 @@ func (c *Client) UpdateBucket(ctx context.Context, input *types.BucketUpdateInpu
 		upReq.Shadow = input.Shadow
 	}
 
+	// Set the object notification configuration if it's provided
+	if input.ObjectNotifications != nil {
+		upReq.ObjectNotifications = input.ObjectNotifications
+	}
+
+	// Set the object regions if they're provided
+	if input.ObjectRegions != nil {
+		upReq.ObjectRegions = input.ObjectRegions
+	}
+
+	// Set the default cache control if it's provided
+	if input.CacheControl != nil {
+		upReq.CacheControl = input.CacheControl
+	}
+
+	// Set the default storage class if it's provided
+	if input.StorageClass != nil {
+		upReq.StorageClass = input.StorageClass
+	}
+
+	// Set the consistency mode if it's provided
+	if input.Consistency != nil {
+		upReq.Consistency = input.Consistency
+	}
+
 	body, err := json.Marshal(upReq)
 	if err != nil {
 		return fmt.Errorf("failed to marshal update request: %w", err)
@@ -151,7 +342,7 @@ func (c *Client) UpdateBucket(ctx context.Context, input *types.BucketUpdateInpu
 func (c *Client) HeadBucket(ctx context.Context, bucketName string) (bool, error) {
 	_, err := c.s3Client.HeadBucket(ctx, &s3.HeadBucketInput{
 		Bucket: aws.String(bucketName),
-	}, withHeader(HeaderAmzIdentityId, c.credentials.AccessKeyID))
+	}, withHeader(HeaderAmzIdentityId, c.credentials.AccessKeyID), withHeader(HeaderTigrisConsistent, "true"))
 
 	exists := true
 	if err != nil {
@@ -173,6 +364,778 @@ func (c *Client) DeleteBucket(ctx context.Context, bucketName string) error {
 	return err
 }
 
+// GetBucketCreationDate returns the creation date of the bucket from the
+// bucket listing. The boolean is false if the bucket is not listed.
+func (c *Client) GetBucketCreationDate(ctx context.Context, bucketName string) (time.Time, bool, error) {
+	paginator := s3.NewListBucketsPaginator(c.s3Client, &s3.ListBucketsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		for _, b := range page.Buckets {
+			if aws.ToString(b.Name) == bucketName {
+				return aws.ToTime(b.CreationDate), true, nil
+			}
+		}
+	}
+
+	return time.Time{}, false, nil
+}
+
+// CreateBucketSnapshot takes a snapshot of a snapshot-enabled bucket. The
+// name is optional. The creation date of the returned snapshot is not set,
+// it is only available from ListBucketSnapshots.
+func (c *Client) CreateBucketSnapshot(ctx context.Context, bucketName, name string) (*types.BucketSnapshot, error) {
+	snapshotHeader := "true"
+	if name != "" {
+		snapshotHeader = fmt.Sprintf("true; name=%s", name)
+	}
+
+	out, err := c.s3Client.CreateBucket(ctx, &s3.CreateBucketInput{
+		Bucket: aws.String(bucketName),
+	}, withHeader(HeaderTigrisSnapshot, snapshotHeader))
+	if err != nil {
+		return nil, err
+	}
+
+	rawResp, ok := awsmiddleware.GetRawResponse(out.ResultMetadata).(*shttp.Response)
+	if !ok || rawResp.Header.Get(HeaderTigrisSnapshotVer) == "" {
+		return nil, errors.New("snapshot version missing from response")
+	}
+
+	return &types.BucketSnapshot{
+		Bucket:  bucketName,
+		Name:    name,
+		Version: rawResp.Header.Get(HeaderTigrisSnapshotVer),
+	}, nil
+}
+
+// ListBucketSnapshots returns all snapshots of the bucket.
+func (c *Client) ListBucketSnapshots(ctx context.Context, bucketName string) ([]types.BucketSnapshot, error) {
+	out, err := c.s3Client.ListBuckets(ctx, &s3.ListBucketsInput{}, withHeader(HeaderTigrisSnapshot, bucketName))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]types.BucketSnapshot, 0, len(out.Buckets))
+	for _, b := range out.Buckets {
+		// Snapshots are listed as "<version>" or "<version>; name=<name>".
+		version, name, _ := strings.Cut(aws.ToString(b.Name), ";")
+		name = strings.TrimPrefix(strings.TrimSpace(name), "name=")
+
+		snapshots = append(snapshots, types.BucketSnapshot{
+			Bucket:       bucketName,
+			Name:         name,
+			Version:      strings.TrimSpace(version),
+			CreationDate: aws.ToTime(b.CreationDate),
+		})
+	}
+
+	return snapshots, nil
+}
+
+// GetBucketEncryption returns the default server-side encryption of the
+// bucket, or nil when it is not configured.
+func (c *Client) GetBucketEncryption(ctx context.Context, bucketName string) (*types.BucketEncryption, error) {
+	out, err := c.s3Client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ServerSideEncryptionConfigurationNotFoundError" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if out.ServerSideEncryptionConfiguration == nil || len(out.ServerSideEncryptionConfiguration.Rules) == 0 {
+		return nil, nil
+	}
+
+	rule := out.ServerSideEncryptionConfiguration.Rules[0]
+	encryption := &types.BucketEncryption{}
+	if rule.ApplyServerSideEncryptionByDefault != nil {
+		encryption.Algorithm = types.ServerSideEncryption(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+	}
+
+	return encryption, nil
+}
+
+// PutBucketEncryption sets the default server-side encryption of the bucket.
+func (c *Client) PutBucketEncryption(ctx context.Context, bucketName string, encryption *types.BucketEncryption) error {
+	_, err := c.s3Client.PutBucketEncryption(ctx, &s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucketName),
+		ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{
+			Rules: []s3types.ServerSideEncryptionRule{
+				{
+					ApplyServerSideEncryptionByDefault: &s3types.ServerSideEncryptionByDefault{
+						SSEAlgorithm: s3types.ServerSideEncryption(encryption.Algorithm),
+					},
+				},
+			},
+		},
+	})
+
+	return err
+}
+
+// DeleteBucketEncryption removes the default server-side encryption
+// configuration of the bucket.
+func (c *Client) DeleteBucketEncryption(ctx context.Context, bucketName string) error {
+	_, err := c.s3Client.DeleteBucketEncryption(ctx, &s3.DeleteBucketEncryptionInput{
+		Bucket: aws.String(bucketName),
+	})
+
+	return err
+}
+
+// GetBucketObjectLock returns the object lock configuration of the bucket.
+// Object lock is disabled when the bucket has no configuration, or when the
+// endpoint does not support object lock.
+func (c *Client) GetBucketObjectLock(ctx context.Context, bucketName string) (*types.BucketObjectLock, error) {
+	out, err := c.s3Client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ObjectLockConfigurationNotFoundError" {
+			return &types.BucketObjectLock{}, nil
+		}
+		return nil, err
+	}
+
+	config := out.ObjectLockConfiguration
+	if config == nil {
+		return &types.BucketObjectLock{}, nil
+	}
+
+	objectLock := &types.BucketObjectLock{
+		Enabled: config.ObjectLockEnabled == s3types.ObjectLockEnabledEnabled,
+	}
+	if config.Rule != nil && config.Rule.DefaultRetention != nil {
+		objectLock.DefaultRetention = &types.ObjectLockRetention{
+			Mode:  types.ObjectLockRetentionMode(config.Rule.DefaultRetention.Mode),
+			Days:  aws.ToInt32(config.Rule.DefaultRetention.Days),
+			Years: aws.ToInt32(config.Rule.DefaultRetention.Years),
+		}
+	}
+
+	return objectLock, nil
+}
+
+// PutBucketObjectLock sets the default retention of a bucket with object lock
+// enabled. A nil retention removes the default retention.
+func (c *Client) PutBucketObjectLock(ctx context.Context, bucketName string, retention *types.ObjectLockRetention) error {
+	config := &s3types.ObjectLockConfiguration{
+		ObjectLockEnabled: s3types.ObjectLockEnabledEnabled,
+	}
+	if retention != nil {
+		defaultRetention := &s3types.DefaultRetention{
+			Mode: s3types.ObjectLockRetentionMode(retention.Mode),
+		}
+		if retention.Days > 0 {
+			defaultRetention.Days = aws.Int32(retention.Days)
+		}
+		if retention.Years > 0 {
+			defaultRetention.Years = aws.Int32(retention.Years)
+		}
+		config.Rule = &s3types.ObjectLockRule{DefaultRetention: defaultRetention}
+	}
+
+	_, err := c.s3Client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
+		Bucket:                  aws.String(bucketName),
+		ObjectLockConfiguration: config,
+	})
+
+	return err
+}
+
+// GetBucketVersioning returns the versioning status of the bucket. It is
+// empty when versioning has never been enabled.
+func (c *Client) GetBucketVersioning(ctx context.Context, bucketName string) (types.BucketVersioningStatus, error) {
+	out, err := c.s3Client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return types.BucketVersioningStatus(out.Status), nil
+}
+
+// PutBucketVersioning enables or suspends versioning of the bucket.
+func (c *Client) PutBucketVersioning(ctx context.Context, bucketName string, status types.BucketVersioningStatus) error {
+	_, err := c.s3Client.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucketName),
+		VersioningConfiguration: &s3types.VersioningConfiguration{
+			Status: s3types.BucketVersioningStatus(status),
+		},
+	})
+
+	return err
+}
+
+// GetBucketCors returns the CORS rules of the bucket, or nil when the bucket
+// has no CORS configuration.
+func (c *Client) GetBucketCors(ctx context.Context, bucketName string) ([]types.CORSRule, error) {
+	out, err := c.s3Client.GetBucketCors(ctx, &s3.GetBucketCorsInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchCORSConfiguration" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rules := make([]types.CORSRule, 0, len(out.CORSRules))
+	for _, rule := range out.CORSRules {
+		rules = append(rules, types.CORSRule{
+			AllowedMethods: rule.AllowedMethods,
+			AllowedOrigins: rule.AllowedOrigins,
+			AllowedHeaders: rule.AllowedHeaders,
+			ExposeHeaders:  rule.ExposeHeaders,
+			MaxAgeSeconds:  aws.ToInt32(rule.MaxAgeSeconds),
+		})
+	}
+
+	return rules, nil
+}
+
+// PutBucketCors replaces the CORS rules of the bucket.
+func (c *Client) PutBucketCors(ctx context.Context, bucketName string, rules []types.CORSRule) error {
+	corsRules := make([]s3types.CORSRule, 0, len(rules))
+	for _, rule := range rules {
+		corsRule := s3types.CORSRule{
+			AllowedMethods: rule.AllowedMethods,
+			AllowedOrigins: rule.AllowedOrigins,
+			AllowedHeaders: rule.AllowedHeaders,
+			ExposeHeaders:  rule.ExposeHeaders,
+		}
+		if rule.MaxAgeSeconds > 0 {
+			corsRule.MaxAgeSeconds = aws.Int32(rule.MaxAgeSeconds)
+		}
+		corsRules = append(corsRules, corsRule)
+	}
+
+	_, err := c.s3Client.PutBucketCors(ctx, &s3.PutBucketCorsInput{
+		Bucket: aws.String(bucketName),
+		CORSConfiguration: &s3types.CORSConfiguration{
+			CORSRules: corsRules,
+		},
+	})
+
+	return err
+}
+
+// DeleteBucketCors removes the CORS configuration of the bucket.
+func (c *Client) DeleteBucketCors(ctx context.Context, bucketName string) error {
+	_, err := c.s3Client.DeleteBucketCors(ctx, &s3.DeleteBucketCorsInput{
+		Bucket: aws.String(bucketName),
+	})
+
+	return err
+}
+
+// GetBucketLifecycle returns the lifecycle rules of the bucket, or nil when
+// the bucket has no lifecycle configuration.
+func (c *Client) GetBucketLifecycle(ctx context.Context, bucketName string) ([]types.LifecycleRule, error) {
+	out, err := c.s3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchLifecycleConfiguration" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	rules := make([]types.LifecycleRule, 0, len(out.Rules))
+	for _, r := range out.Rules {
+		rule := types.LifecycleRule{
+			ID:      aws.ToString(r.ID),
+			Enabled: r.Status == s3types.ExpirationStatusEnabled,
+			Prefix:  aws.ToString(r.Prefix), //nolint:staticcheck // Rules created before filters were introduced.
+		}
+
+		switch filter := r.Filter.(type) {
+		case *s3types.LifecycleRuleFilterMemberPrefix:
+			rule.Prefix = filter.Value
+		case *s3types.LifecycleRuleFilterMemberTag:
+			rule.Tags = map[string]string{aws.ToString(filter.Value.Key): aws.ToString(filter.Value.Value)}
+		case *s3types.LifecycleRuleFilterMemberAnd:
+			rule.Prefix = aws.ToString(filter.Value.Prefix)
+			rule.Tags = make(map[string]string, len(filter.Value.Tags))
+			for _, tag := range filter.Value.Tags {
+				rule.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+		}
+
+		if r.Expiration != nil {
+			rule.Expiration = &types.LifecycleExpiration{
+				Days:                      aws.ToInt32(r.Expiration.Days),
+				Date:                      r.Expiration.Date,
+				ExpiredObjectDeleteMarker: aws.ToBool(r.Expiration.ExpiredObjectDeleteMarker),
+			}
+		}
+
+		for _, transition := range r.Transitions {
+			rule.Transitions = append(rule.Transitions, types.LifecycleTransition{
+				Days:         aws.ToInt32(transition.Days),
+				Date:         transition.Date,
+				StorageClass: types.StorageClass(transition.StorageClass),
+			})
+		}
+
+		if r.NoncurrentVersionExpiration != nil {
+			rule.NoncurrentVersionExpiration = &types.NoncurrentVersionExpiration{
+				NoncurrentDays:          aws.ToInt32(r.NoncurrentVersionExpiration.NoncurrentDays),
+				NewerNoncurrentVersions: aws.ToInt32(r.NoncurrentVersionExpiration.NewerNoncurrentVersions),
+			}
+		}
+
+		if r.AbortIncompleteMultipartUpload != nil {
+			rule.AbortIncompleteMultipartUploadDays = aws.ToInt32(r.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// PutBucketLifecycle replaces the lifecycle rules of the bucket.
+func (c *Client) PutBucketLifecycle(ctx context.Context, bucketName string, rules []types.LifecycleRule) error {
+	lifecycleRules := make([]s3types.LifecycleRule, 0, len(rules))
+	for _, rule := range rules {
+		r := s3types.LifecycleRule{
+			ID:     aws.String(rule.ID),
+			Status: s3types.ExpirationStatusDisabled,
+			Filter: expandLifecycleRuleFilter(rule.Prefix, rule.Tags),
+		}
+		if rule.Enabled {
+			r.Status = s3types.ExpirationStatusEnabled
+		}
+
+		if rule.Expiration != nil {
+			r.Expiration = &s3types.LifecycleExpiration{Date: rule.Expiration.Date}
+			if rule.Expiration.Days > 0 {
+				r.Expiration.Days = aws.Int32(rule.Expiration.Days)
+			}
+			if rule.Expiration.ExpiredObjectDeleteMarker {
+				r.Expiration.ExpiredObjectDeleteMarker = aws.Bool(true)
+			}
+		}
+
+		for _, transition := range rule.Transitions {
+			t := s3types.Transition{
+				Date:         transition.Date,
+				StorageClass: s3types.TransitionStorageClass(transition.StorageClass),
+			}
+			if transition.Date == nil {
+				t.Days = aws.Int32(transition.Days)
+			}
+			r.Transitions = append(r.Transitions, t)
+		}
+
+		if rule.NoncurrentVersionExpiration != nil {
+			r.NoncurrentVersionExpiration = &s3types.NoncurrentVersionExpiration{
+				NoncurrentDays: aws.Int32(rule.NoncurrentVersionExpiration.NoncurrentDays),
+			}
+			if rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0 {
+				r.NoncurrentVersionExpiration.NewerNoncurrentVersions = aws.Int32(rule.NoncurrentVersionExpiration.NewerNoncurrentVersions)
+			}
+		}
+
+		if rule.AbortIncompleteMultipartUploadDays > 0 {
+			r.AbortIncompleteMultipartUpload = &s3types.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: aws.Int32(rule.AbortIncompleteMultipartUploadDays),
+			}
+		}
+
+		lifecycleRules = append(lifecycleRules, r)
+	}
+
+	_, err := c.s3Client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+		LifecycleConfiguration: &s3types.BucketLifecycleConfiguration{
+			Rules: lifecycleRules,
+		},
+	})
+
+	return err
+}
+
+// DeleteBucketLifecycle removes the lifecycle configuration of the bucket.
+func (c *Client) DeleteBucketLifecycle(ctx context.Context, bucketName string) error {
+	_, err := c.s3Client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{
+		Bucket: aws.String(bucketName),
+	})
+
+	return err
+}
+
+// expandLifecycleRuleFilter builds the narrowest S3 filter matching the prefix
+// and all of the tags, since a filter with several conditions needs an And.
+func expandLifecycleRuleFilter(prefix string, tags map[string]string) s3types.LifecycleRuleFilter {
+	if len(tags) == 0 {
+		return &s3types.LifecycleRuleFilterMemberPrefix{Value: prefix}
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	s3Tags := make([]s3types.Tag, 0, len(keys))
+	for _, key := range keys {
+		s3Tags = append(s3Tags, s3types.Tag{Key: aws.String(key), Value: aws.String(tags[key])})
+	}
+
+	if prefix == "" && len(s3Tags) == 1 {
+		return &s3types.LifecycleRuleFilterMemberTag{Value: s3Tags[0]}
+	}
+
+	and := s3types.LifecycleRuleAndOperator{Tags: s3Tags}
+	if prefix != "" {
+		and.Prefix = aws.String(prefix)
+	}
+
+	return &s3types.LifecycleRuleFilterMemberAnd{Value: and}
+}
+
+// GetBucketPolicy returns the policy document of the bucket, or an empty
+// string when the bucket has no policy.
+func (c *Client) GetBucketPolicy(ctx context.Context, bucketName string) (string, error) {
+	out, err := c.s3Client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchBucketPolicy" {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return aws.ToString(out.Policy), nil
+}
+
+// PutBucketPolicy replaces the policy document of the bucket.
+func (c *Client) PutBucketPolicy(ctx context.Context, bucketName, policy string) error {
+	_, err := c.s3Client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+		Policy: aws.String(policy),
+	})
+
+	return err
+}
+
+// DeleteBucketPolicy removes the policy of the bucket.
+func (c *Client) DeleteBucketPolicy(ctx context.Context, bucketName string) error {
+	_, err := c.s3Client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+	})
+
+	return err
+}
+
+// GetBucketLogging returns the server access logging configuration of the
+// bucket, or nil when access logging is disabled.
+func (c *Client) GetBucketLogging(ctx context.Context, bucketName string) (*types.BucketLogging, error) {
+	out, err := c.s3Client.GetBucketLogging(ctx, &s3.GetBucketLoggingInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if out.LoggingEnabled == nil {
+		return nil, nil
+	}
+
+	return &types.BucketLogging{
+		TargetBucket: aws.ToString(out.LoggingEnabled.TargetBucket),
+		TargetPrefix: aws.ToString(out.LoggingEnabled.TargetPrefix),
+	}, nil
+}
+
+// PutBucketLogging sets the server access logging configuration of the
+// bucket. A nil configuration disables access logging.
+func (c *Client) PutBucketLogging(ctx context.Context, bucketName string, logging *types.BucketLogging) error {
+	status := &s3types.BucketLoggingStatus{}
+	if logging != nil {
+		status.LoggingEnabled = &s3types.LoggingEnabled{
+			TargetBucket: aws.String(logging.TargetBucket),
+			TargetPrefix: aws.String(logging.TargetPrefix),
+		}
+	}
+
+	_, err := c.s3Client.PutBucketLogging(ctx, &s3.PutBucketLoggingInput{
+		Bucket:              aws.String(bucketName),
+		BucketLoggingStatus: status,
+	})
+
+	return err
+}
+
+// GetBucketTags returns the tags of the bucket. A bucket without tags
+// returns an empty map.
+func (c *Client) GetBucketTags(ctx context.Context, bucketName string) (map[string]string, error) {
+	out, err := c.s3Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchTagSet" {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	tags := make(map[string]string, len(out.TagSet))
+	for _, tag := range out.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	return tags, nil
+}
+
+// UpdateBucketTags replaces all tags of the bucket. An empty map removes
+// all tags.
+func (c *Client) UpdateBucketTags(ctx context.Context, bucketName string, tags map[string]string) error {
+	if len(tags) == 0 {
+		_, err := c.s3Client.DeleteBucketTagging(ctx, &s3.DeleteBucketTaggingInput{
+			Bucket: aws.String(bucketName),
+		})
+
+		return err
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tagSet := make([]s3types.Tag, 0, len(tags))
+	for _, key := range keys {
+		tagSet = append(tagSet, s3types.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(tags[key]),
+		})
+	}
+
+	_, err := c.s3Client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
+		Bucket:  aws.String(bucketName),
+		Tagging: &s3types.Tagging{TagSet: tagSet},
+	})
+
+	return err
+}
+
+// IsBucketEmpty reports whether the bucket contains no objects, including
+// noncurrent object versions and delete markers.
+func (c *Client) IsBucketEmpty(ctx context.Context, bucketName string) (bool, error) {
+	versions, err := c.s3Client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
+		Bucket:  aws.String(bucketName),
+		MaxKeys: aws.Int32(1),
+	})
+	if err == nil {
+		return len(versions.Versions) == 0 && len(versions.DeleteMarkers) == 0, nil
+	}
+
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "NotImplemented" {
+		return false, err
+	}
+
+	// Fall back to listing current objects when versions are not supported.
+	out, err := c.s3Client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucketName),
+		MaxKeys: aws.Int32(1),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(out.Contents) == 0, nil
+}
+
+// EmptyBucket deletes every object in the bucket, including all object
+// versions and delete markers, and aborts in-progress multipart uploads.
+// Objects are deleted in batches that are processed in parallel.
+func (c *Client) EmptyBucket(ctx context.Context, bucketName string) error {
+	if err := c.abortMultipartUploads(ctx, bucketName); err != nil {
+		return err
+	}
+
+	batches := make(chan []s3types.ObjectIdentifier)
+	errs := make(chan error, emptyBucketWorkers+1)
+	var deleted atomic.Int64
+
+	var wg sync.WaitGroup
+	for i := 0; i < emptyBucketWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if err := c.deleteObjectBatch(ctx, bucketName, batch); err != nil {
+					errs <- err
+					return
+				}
+
+				tflog.Info(ctx, "Deleted objects from bucket", map[string]interface{}{
+					"bucket_name": bucketName,
+					"deleted":     deleted.Add(int64(len(batch))),
+				})
+			}
+		}()
+	}
+
+	listErr := c.listObjectBatches(ctx, bucketName, batches, errs)
+	close(batches)
+	wg.Wait()
+	close(errs)
+
+	if listErr != nil {
+		return listErr
+	}
+
+	return <-errs
+}
+
+// listObjectBatches sends every object version in the bucket to batches, in
+// groups of at most deleteObjectsBatchSize. It stops early if a worker
+// reports an error.
+func (c *Client) listObjectBatches(ctx context.Context, bucketName string, batches chan<- []s3types.ObjectIdentifier, errs <-chan error) error {
+	send := func(batch []s3types.ObjectIdentifier) error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		select {
+		case batches <- batch:
+			return nil
+		case err := <-errs:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	paginator := s3.NewListObjectVersionsPaginator(c.s3Client, &s3.ListObjectVersionsInput{
+		Bucket:  aws.String(bucketName),
+		MaxKeys: aws.Int32(deleteObjectsBatchSize),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NotImplemented" {
+				// Fall back to listing current objects when versions are not supported.
+				return c.listCurrentObjectBatches(ctx, bucketName, send)
+			}
+			return fmt.Errorf("failed to list object versions: %w", err)
+		}
+
+		batch := make([]s3types.ObjectIdentifier, 0, len(page.Versions)+len(page.DeleteMarkers))
+		for _, v := range page.Versions {
+			batch = append(batch, s3types.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+		}
+		for _, m := range page.DeleteMarkers {
+			batch = append(batch, s3types.ObjectIdentifier{Key: m.Key, VersionId: m.VersionId})
+		}
+
+		if err := send(batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) listCurrentObjectBatches(ctx context.Context, bucketName string, send func([]s3types.ObjectIdentifier) error) error {
+	paginator := s3.NewListObjectsV2Paginator(c.s3Client, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucketName),
+		MaxKeys: aws.Int32(deleteObjectsBatchSize),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		batch := make([]s3types.ObjectIdentifier, 0, len(page.Contents))
+		for _, o := range page.Contents {
+			batch = append(batch, s3types.ObjectIdentifier{Key: o.Key})
+		}
+
+		if err := send(batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) deleteObjectBatch(ctx context.Context, bucketName string, batch []s3types.ObjectIdentifier) error {
+	out, err := c.s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String(bucketName),
+		Delete: &s3types.Delete{
+			Objects: batch,
+			Quiet:   aws.Bool(true),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete objects: %w", err)
+	}
+
+	if len(out.Errors) > 0 {
+		e := out.Errors[0]
+		return fmt.Errorf("failed to delete %d objects, first error on %q: %s", len(out.Errors), aws.ToString(e.Key), aws.ToString(e.Message))
+	}
+
+	return nil
+}
+
+func (c *Client) abortMultipartUploads(ctx context.Context, bucketName string) error {
+	paginator := s3.NewListMultipartUploadsPaginator(c.s3Client, &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(bucketName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list multipart uploads: %w", err)
+		}
+
+		for _, upload := range page.Uploads {
+			_, err := c.s3Client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:   aws.String(bucketName),
+				Key:      upload.Key,
+				UploadId: upload.UploadId,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to abort multipart upload of %q: %w", aws.ToString(upload.Key), err)
+			}
+		}
+
+		if len(page.Uploads) > 0 {
+			tflog.Info(ctx, "Aborted multipart uploads", map[string]interface{}{
+				"bucket_name": bucketName,
+				"aborted":     len(page.Uploads),
+			})
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) GetBucketMetadata(ctx context.Context, bucketName string) (*types.BucketMetadata, error) {
 	params := map[string]string{
 		"metadata": "",
@@ -182,6 +1145,9 @@ func (c *Client) GetBucketMetadata(ctx context.Context, bucketName string) (*typ
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// Read the latest metadata so that it reflects preceding updates
+	req.Header.Set(HeaderTigrisConsistent, "true")
+
 	//nolint:contextcheck
 	resp, err := c.doRequestWithRetry(req)
 	if err != nil {
@@ -205,13 +1171,14 @@ func (c *Client) GetBucketMetadata(ctx context.Context, bucketName string) (*typ
 
 func (c *Client) FindBucketWithRetry(ctx context.Context, bucketName string) (bool, error) {
 	maxRetries := 5
-	backoffDelay := 3 * time.Second
-	maxBackoffDelay := 60 * time.Second
+	backoffDelay := c.backoffDelay
+	maxBackoffDelay := c.maxBackoffDelay
 
 	var exists bool
+	var err error
 
 	for i := 0; i < maxRetries; i++ {
-		exists, err := c.HeadBucket(ctx, bucketName)
+		exists, err = c.HeadBucket(ctx, bucketName)
 		if err != nil {
 			return false, err
 		}
@@ -235,10 +1202,176 @@ func (c *Client) FindBucketWithRetry(ctx context.Context, bucketName string) (bo
 	return exists, nil
 }
 
+// iamAccessKey is an access key in the responses of the IAM API.
+type iamAccessKey struct {
+	UserName        string    `xml:"UserName"`
+	AccessKeyID     string    `xml:"AccessKeyId"`
+	SecretAccessKey string    `xml:"SecretAccessKey"`
+	Status          string    `xml:"Status"`
+	CreateDate      time.Time `xml:"CreateDate"`
+}
+
+func (k *iamAccessKey) accessKey() *types.AccessKey {
+	return &types.AccessKey{
+		Name:            k.UserName,
+		AccessKeyID:     k.AccessKeyID,
+		SecretAccessKey: k.SecretAccessKey,
+		Status:          types.AccessKeyStatus(k.Status),
+		CreateDate:      k.CreateDate,
+	}
+}
+
+// CreateAccessKey creates an access key with the name. The secret of the key
+// is only returned here.
+func (c *Client) CreateAccessKey(ctx context.Context, name string) (*types.AccessKey, error) {
+	var out struct {
+		AccessKey iamAccessKey `xml:"CreateAccessKeyResult>AccessKey"`
+	}
+	// The request is not retried, a retry after the server created the key
+	// would create another one whose secret is lost.
+	if err := c.doIAMRequest(ctx, "CreateAccessKey", url.Values{"UserName": {name}}, &out, false); err != nil {
+		return nil, err
+	}
+
+	return out.AccessKey.accessKey(), nil
+}
+
+// GetAccessKey returns the access key without its secret, or nil when the key
+// does not exist. The owner of the key is looked up by its ID, as listing keys
+// without a user name only returns the keys of the caller.
+func (c *Client) GetAccessKey(ctx context.Context, accessKeyID string) (*types.AccessKey, error) {
+	var lastUsed struct {
+		UserName string `xml:"GetAccessKeyLastUsedResult>UserName"`
+	}
+	err := c.doIAMRequest(ctx, "GetAccessKeyLastUsed", url.Values{"AccessKeyId": {accessKeyID}}, &lastUsed, true)
+	if isNoSuchEntity(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"UserName": {lastUsed.UserName}}
+	for {
+		var out struct {
+			AccessKeys  []iamAccessKey `xml:"ListAccessKeysResult>AccessKeyMetadata>member"`
+			IsTruncated bool           `xml:"ListAccessKeysResult>IsTruncated"`
+			Marker      string         `xml:"ListAccessKeysResult>Marker"`
+		}
+		if err := c.doIAMRequest(ctx, "ListAccessKeys", params, &out, true); err != nil {
+			return nil, err
+		}
+
+		for _, key := range out.AccessKeys {
+			if key.AccessKeyID == accessKeyID {
+				return key.accessKey(), nil
+			}
+		}
+
+		if !out.IsTruncated || out.Marker == "" {
+			return nil, nil
+		}
+		params.Set("Marker", out.Marker)
+	}
+}
+
+// UpdateAccessKeyStatus activates or deactivates the access key.
+func (c *Client) UpdateAccessKeyStatus(ctx context.Context, accessKeyID string, status types.AccessKeyStatus) error {
+	return c.doIAMRequest(ctx, "UpdateAccessKey", url.Values{
+		"AccessKeyId": {accessKeyID},
+		"Status":      {string(status)},
+	}, nil, true)
+}
+
+// DeleteAccessKey deletes the access key.
+func (c *Client) DeleteAccessKey(ctx context.Context, accessKeyID string) error {
+	return c.doIAMRequest(ctx, "DeleteAccessKey", url.Values{"AccessKeyId": {accessKeyID}}, nil, true)
+}
+
+// isUnsupportedOperation reports whether the error means the endpoint does
+// not implement the operation.
+func isUnsupportedOperation(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.ErrorCode() {
+	case "NotImplemented", "MethodNotAllowed":
+		return true
+	default:
+		return false
+	}
+}
+
+// isNoSuchEntity reports whether the error means the IAM entity does not
+// exist.
+func isNoSuchEntity(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchEntity"
+}
+
+// doIAMRequest sends an action of the IAM query API and decodes the XML
+// response into out. Errors are returned as smithy.APIError. Actions that
+// are not idempotent must not be retried.
+func (c *Client) doIAMRequest(ctx context.Context, action string, params url.Values, out interface{}, retry bool) error {
+	form := url.Values{}
+	for key, values := range params {
+		form[key] = values
+	}
+	form.Set("Action", action)
+	form.Set("Version", iamAPIVersion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.iamEndpoint+"/", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", action, err)
+	}
+	req.Header.Set(HeaderContentType, "application/x-www-form-urlencoded; charset=utf-8")
+
+	var resp *http.Response
+	if retry {
+		//nolint:contextcheck
+		resp, err = c.retryRequest(req, c.doSignedIAMRequest)
+	} else {
+		//nolint:contextcheck
+		resp, err = c.doSignedIAMRequest(req)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send %s request: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		if err := xml.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Code == "" {
+			return fmt.Errorf("%s request failed with code: %d", action, resp.StatusCode)
+		}
+
+		return &smithy.GenericAPIError{Code: errResp.Code, Message: errResp.Message}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to read %s response: %w", action, err)
+	}
+
+	return nil
+}
+
 func (c *Client) doRequestWithRetry(req *http.Request) (*http.Response, error) {
+	return c.retryRequest(req, c.doSignedRequest)
+}
+
+// retryRequest sends the request with send, retrying on server-side errors.
+func (c *Client) retryRequest(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
 	maxRetries := 5
-	backoffDelay := 3 * time.Second
-	maxBackoffDelay := 60 * time.Second
+	backoffDelay := c.backoffDelay
+	maxBackoffDelay := c.maxBackoffDelay
 
 	var resp *http.Response
 	var err error
@@ -250,13 +1383,14 @@ func (c *Client) doRequestWithRetry(req *http.Request) (*http.Response, error) {
 			return nil, fmt.Errorf("failed to clone request: %w", err)
 		}
 
-		resp, err = c.doSignedRequest(clonedReq)
+		resp, err = send(clonedReq)
 		if err != nil {
 			return nil, fmt.Errorf("failed to send request: %w", err)
 		}
 
-		// Check if the response status code indicates a server-side error (5xx)
-		if resp.StatusCode >= 500 {
+		// Check if the response status code indicates a server-side error (5xx).
+		// The response of the last attempt is returned to the caller as is.
+		if resp.StatusCode >= 500 && i < maxRetries-1 {
 			resp.Body.Close()
 
 			// Exponential backoff before retrying
@@ -287,6 +1421,45 @@ func (c *Client) doSignedRequest(req *http.Request) (*http.Response, error) {
 	return c.httpClient.Do(req)
 }
 
+// doSignedIAMRequest signs and sends a request of the IAM query API. Its
+// form-encoded headers are set by doIAMRequest and kept as they are.
+func (c *Client) doSignedIAMRequest(req *http.Request) (*http.Response, error) {
+	if err := c.signPayload(req); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// Endpoint returns the endpoint of the Tigris object storage service.
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// EndpointURL returns the endpoint of the Tigris object storage service to use
+// with S3 clients.
+func (c *Client) EndpointURL() string {
+	return c.endpointURL
+}
+
+// Region returns the region to use with S3 clients.
+func (c *Client) Region() string {
+	return c.region
+}
+
+// BucketVirtualHostURL returns the virtual-hosted style URL of the bucket.
+func (c *Client) BucketVirtualHostURL(bucketName string) string {
+	u, err := url.Parse(c.endpointURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	u.Host = bucketName + "." + u.Host
+	u.Path = ""
+
+	return u.String()
+}
+
 func (c *Client) bucketURL(bucketName string, queryParams map[string]string) string {
 	baseURL := fmt.Sprintf("%s/%s", c.endpoint, bucketName)
 	if len(queryParams) == 0 {
@@ -303,13 +1476,18 @@ func (c *Client) bucketURL(bucketName string, queryParams map[string]string) str
 }
 
 func (c *Client) signRequest(req *http.Request) error {
-	// Get the current time for the request
-	now := time.Now()
-
 	// Set default headers
 	req.Header.Set(HeaderContentType, "application/json")
 	req.Header.Set(HeaderAccept, "application/json")
 
+	return c.signPayload(req)
+}
+
+// signPayload signs the request with its headers as they are.
+func (c *Client) signPayload(req *http.Request) error {
+	// Get the current time for the request
+	now := time.Now()
+
 	// Buffer the request body if it exists
 	var bodyBytes []byte
 	var payloadHash string
@@ -334,7 +1512,7 @@ func (c *Client) signRequest(req *http.Request) error {
 	req.Header.Set(HeaderAmzContentSha, payloadHash)
 
 	// Sign the request using the signer
-	err := c.signer.SignHTTP(context.TODO(), c.credentials, req, payloadHash, "s3", DefaultRegion, now)
+	err := c.signer.SignHTTP(context.TODO(), c.credentials, req, payloadHash, c.signingService(req.URL), c.region, now)
 	if err != nil {
 		return fmt.Errorf("failed to sign request: %w", err)
 	}
@@ -342,6 +1520,15 @@ func (c *Client) signRequest(req *http.Request) error {
 	return nil
 }
 
+// signingService returns the service the request to the URL is signed for.
+func (c *Client) signingService(u *url.URL) string {
+	if iamURL, err := url.Parse(c.iamEndpoint); err == nil && u.Host == iamURL.Host {
+		return "iam"
+	}
+
+	return "s3"
+}
+
 func cloneRequest(req *http.Request) (*http.Request, error) {
 	// Create a shallow copy of the request
 	clonedReq := req.Clone(req.Context())
@@ -0,0 +1,240 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tigrisdata/terraform-provider-tigris/internal/httpreplay"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/memory"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+// newReplayClient returns a Client whose traffic is served from the fixture
+// testdata/fixtures/<name>.json. When TIGRIS_HTTP_RECORD is set, requests are
+// sent to the real endpoint using the AWS_ACCESS_KEY_ID and
+// AWS_SECRET_ACCESS_KEY credentials and the fixture is re-recorded.
+func newReplayClient(t *testing.T, name string) *Client {
+	t.Helper()
+
+	endpoint := DefaultEndpoint
+	accessKey := "replay-access-key"
+	secretKey := "replay-secret-key"
+
+	mode := httpreplay.ModeFromEnv()
+	if mode == httpreplay.ModeRecord {
+		if v := os.Getenv("TIGRIS_ENDPOINT"); v != "" {
+			endpoint = v
+		}
+		accessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+		secretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+
+	rec, err := httpreplay.New(filepath.Join("testdata", "fixtures", name+".json"), httpreplay.Config{
+		Mode:    mode,
+		Secrets: []string{accessKey, secretKey},
+	})
+	if err != nil {
+		t.Fatalf("unable to load fixture, %v", err)
+	}
+	t.Cleanup(func() {
+		if err := rec.Stop(); err != nil {
+			t.Errorf("unable to save fixture, %v", err)
+		}
+	})
+
+	client, err := NewClient(endpoint, accessKey, secretKey,
+		WithTransport(rec),
+		WithRetryBackoff(time.Millisecond, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unable to create client, %v", err)
+	}
+
+	return client
+}
+
+func TestClientUpdateBucket(t *testing.T) {
+	acl := types.BucketCannedACLPublicRead
+
+	tests := []struct {
+		fixture string
+		bucket  string
+		wantErr string
+	}{
+		{fixture: "update_bucket_ok", bucket: "tf-replay-bucket"},
+		{fixture: "update_bucket_retry", bucket: "tf-replay-bucket"},
+		{fixture: "update_bucket_error_message", bucket: "tf-replay-missing", wantErr: "update failed with error: The specified bucket does not exist"},
+		{fixture: "update_bucket_error_undecodable", bucket: "tf-replay-forbidden", wantErr: "request failed with code: 403"},
+		{fixture: "update_bucket_retry_exhausted", bucket: "tf-replay-bucket", wantErr: "update failed with error: Please reduce your request rate."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			client := newReplayClient(t, tt.fixture)
+
+			err := client.UpdateBucket(context.Background(), &types.BucketUpdateInput{
+				Bucket: tt.bucket,
+				ACL:    &acl,
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestClientGetBucketMetadata(t *testing.T) {
+	client := newReplayClient(t, "get_bucket_metadata")
+
+	metadata, err := client.GetBucketMetadata(context.Background(), "tf-replay-bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if metadata.Name != "tf-replay-bucket" {
+		t.Errorf("name = %q, want %q", metadata.Name, "tf-replay-bucket")
+	}
+	if acl := metadata.GetBucketCannedACL(); acl != types.BucketCannedACLPublicRead {
+		t.Errorf("acl = %q, want %q", acl, types.BucketCannedACLPublicRead)
+	}
+	if metadata.GetPublicObjectsListEnabled() {
+		t.Error("public objects list should be disabled")
+	}
+	if metadata.Website == nil || metadata.Website.DomainName != "assets.example.com" {
+		t.Errorf("unexpected website config: %+v", metadata.Website)
+	}
+	if metadata.Shadow == nil || metadata.Shadow.SecretKey != httpreplay.Redacted {
+		t.Errorf("shadow secret key should be scrubbed in fixture: %+v", metadata.Shadow)
+	}
+}
+
+func TestClientHeadBucket(t *testing.T) {
+	client := newReplayClient(t, "head_bucket")
+
+	exists, err := client.HeadBucket(context.Background(), "tf-replay-bucket")
+	if err != nil || !exists {
+		t.Fatalf("expected existing bucket, got exists=%t err=%v", exists, err)
+	}
+
+	exists, err = client.HeadBucket(context.Background(), "tf-replay-missing")
+	if err != nil || exists {
+		t.Fatalf("expected missing bucket, got exists=%t err=%v", exists, err)
+	}
+}
+
+// newMemoryClient returns a Client backed by a fresh in-memory backend.
+func newMemoryClient(t *testing.T) *Client {
+	t.Helper()
+
+	client, err := NewClient(memory.Endpoint, "memory", "memory", WithTransport(memory.NewBackend()), WithIAMEndpoint(memory.IAMEndpoint))
+	if err != nil {
+		t.Fatalf("unable to create client, %v", err)
+	}
+
+	return client
+}
+
+func TestClientBucketVirtualHostURL(t *testing.T) {
+	client, err := NewClient(DefaultEndpoint, "access-key", "secret-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := client.BucketVirtualHostURL("my-bucket"), "https://my-bucket.fly.storage.tigris.dev"; got != want {
+		t.Errorf("BucketVirtualHostURL() = %q, want %q", got, want)
+	}
+}
+
+// headerTransport records the requests sent through it.
+type headerTransport struct {
+	next     http.RoundTripper
+	requests []*http.Request
+}
+
+func (h *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.requests = append(h.requests, req)
+	return h.next.RoundTrip(req)
+}
+
+func TestClientConsistentReads(t *testing.T) {
+	ctx := context.Background()
+	transport := &headerTransport{next: memory.NewBackend()}
+
+	client, err := NewClient(memory.Endpoint, "memory", "memory", WithTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-consistent"}); err != nil {
+		t.Fatal(err)
+	}
+
+	transport.requests = nil
+	if _, err := client.HeadBucket(ctx, "tf-consistent"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetBucketMetadata(ctx, "tf-consistent"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(transport.requests) != 2 {
+		t.Fatalf("sent %d requests, want 2", len(transport.requests))
+	}
+	for _, req := range transport.requests {
+		if got := req.Header.Get(HeaderTigrisConsistent); got != "true" {
+			t.Errorf("%s %s: %s = %q, want %q", req.Method, req.URL, HeaderTigrisConsistent, got, "true")
+		}
+	}
+}
+
+func TestClientIAMRequests(t *testing.T) {
+	ctx := context.Background()
+
+	// Every IAM request fails with a server-side error.
+	var requests []*http.Request
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req)
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Request:    req,
+		}, nil
+	})
+
+	client, err := NewClient(memory.Endpoint, "memory", "memory", WithTransport(transport), WithIAMEndpoint(memory.IAMEndpoint), WithRetryBackoff(time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateAccessKey(ctx, "tf-app"); err == nil {
+		t.Fatal("expected creating the access key to fail")
+	}
+	if len(requests) != 1 {
+		t.Errorf("sent CreateAccessKey %d times, want it not to be retried", len(requests))
+	}
+
+	requests = nil
+	if _, err := client.GetAccessKey(ctx, "tid_missing"); err == nil {
+		t.Fatal("expected reading the access key to fail")
+	}
+	if len(requests) != 5 {
+		t.Errorf("sent GetAccessKeyLastUsed %d times, want 5 attempts", len(requests))
+	}
+
+	for _, req := range requests {
+		if got, want := req.Header.Get(HeaderContentType), "application/x-www-form-urlencoded; charset=utf-8"; got != want {
+			t.Errorf("%s = %q, want %q", HeaderContentType, got, want)
+		}
+	}
+}
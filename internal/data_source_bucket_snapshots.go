@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+)
+
+func dataSourceTigrisBucketSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Description:        "Lists the snapshots of a Tigris bucket.",
+		ReadWithoutTimeout: dataSourceBucketSnapshotsRead,
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the Tigris bucket.",
+			},
+			names.AttrSnapshots: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The snapshots of the bucket.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						names.AttrName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the snapshot.",
+						},
+						names.AttrVersion: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version that identifies the snapshot.",
+						},
+						names.AttrCreationDate: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the snapshot was taken, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBucketSnapshotsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+
+	tflog.Info(ctx, "Fetching bucket snapshots", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	snapshots, err := svc.ListBucketSnapshots(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to list bucket snapshots, %w", err))
+	}
+
+	items := make([]interface{}, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		items = append(items, map[string]interface{}{
+			names.AttrName:         snapshot.Name,
+			names.AttrVersion:      snapshot.Version,
+			names.AttrCreationDate: snapshot.CreationDate.Format(time.RFC3339),
+		})
+	}
+
+	d.SetId(bucketName)
+	d.Set(names.AttrSnapshots, items)
+
+	return nil
+}
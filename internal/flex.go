@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// expandStringSet converts a set of strings from the schema into a sorted
+// string slice.
+func expandStringSet(set *schema.Set) []string {
+	values := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		values = append(values, v.(string))
+	}
+	sort.Strings(values)
+
+	return values
+}
+
+// flattenStringSet converts a string slice into a set of strings for the schema.
+func flattenStringSet(values []string) *schema.Set {
+	set := schema.NewSet(schema.HashString, nil)
+	for _, v := range values {
+		set.Add(v)
+	}
+
+	return set
+}
+
+// expandStringMap converts a map of strings from the schema into a string map.
+func expandStringMap(m map[string]interface{}) map[string]string {
+	values := make(map[string]string, len(m))
+	for k, v := range m {
+		values[k] = v.(string)
+	}
+
+	return values
+}
@@ -0,0 +1,334 @@
+// Package httpreplay implements an http.RoundTripper that records real HTTP
+// interactions to a fixture file and replays them deterministically. It is
+// used to exercise the Tigris client against real server responses without
+// requiring credentials.
+package httpreplay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode is the operating mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the fixture file and never touches the network.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests to the real server and saves the interactions.
+	ModeRecord
+)
+
+// EnvRecord is the environment variable that switches fixtures to record mode.
+const EnvRecord = "TIGRIS_HTTP_RECORD"
+
+// Redacted is the placeholder that replaces scrubbed secrets.
+const Redacted = "REDACTED"
+
+// scrubbedHeaders are never written to a fixture, either because they carry
+// credentials or because they change on every request.
+var scrubbedHeaders = []string{
+	"Authorization",
+	"X-Amz-Security-Token",
+	"X-Amz-Date",
+	"X-Amz-Content-Sha256",
+	"S3-Identity-Id",
+	"User-Agent",
+	"Amz-Sdk-Invocation-Id",
+	"Amz-Sdk-Request",
+}
+
+// sensitiveKeys are JSON object keys whose values are redacted from bodies.
+var sensitiveKeys = map[string]bool{
+	"access_key":      true,
+	"secret_key":      true,
+	"AccessKeyId":     true,
+	"SecretAccessKey": true,
+	"SessionToken":    true,
+	"token":           true,
+	"password":        true,
+}
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction
+// matches the outgoing request.
+var ErrNoInteraction = errors.New("no recorded interaction matches request")
+
+// Config configures a Recorder.
+type Config struct {
+	// Mode selects between recording and replaying.
+	Mode Mode
+
+	// Transport is used to reach the real server in record mode. It defaults
+	// to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// Secrets are literal values, such as access keys, that are replaced with
+	// Redacted wherever they appear in a recorded interaction.
+	Secrets []string
+}
+
+// Request is the recorded form of an HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	Host   string      `json:"host"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is the recorded form of an HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the on-disk fixture format.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+type Recorder struct {
+	path string
+	cfg  Config
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// ModeFromEnv returns ModeRecord when EnvRecord is set to a non-empty value
+// and ModeReplay otherwise.
+func ModeFromEnv() Mode {
+	if os.Getenv(EnvRecord) != "" {
+		return ModeRecord
+	}
+
+	return ModeReplay
+}
+
+// New returns a Recorder backed by the fixture at path. In replay mode the
+// fixture must exist; in record mode it is overwritten by Stop.
+func New(path string, cfg Config) (*Recorder, error) {
+	if cfg.Transport == nil {
+		cfg.Transport = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		path:     path,
+		cfg:      cfg,
+		cassette: &Cassette{},
+	}
+
+	if cfg.Mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recReq := r.recordRequest(req, body)
+
+	if r.cfg.Mode == ModeReplay {
+		return r.replay(req, recReq)
+	}
+
+	resp, err := r.cfg.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: recReq,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.scrubHeader(resp.Header),
+			Body:       r.scrubBody(respBody),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Stop saves the recorded interactions in record mode. It is a no-op in
+// replay mode.
+func (r *Recorder) Stop() error {
+	if r.cfg.Mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o600)
+}
+
+// replay returns the first unused interaction that matches the request.
+// Interactions are consumed in order so that repeated identical requests,
+// such as retries, observe the recorded sequence of responses.
+func (r *Recorder) replay(req *http.Request, recReq Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !matches(in.Request, recReq) {
+			continue
+		}
+		r.used[i] = true
+
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s%s?%s", ErrNoInteraction, recReq.Method, recReq.Host, recReq.Path, recReq.Query)
+}
+
+func (r *Recorder) recordRequest(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		Host:   req.URL.Host,
+		Path:   req.URL.EscapedPath(),
+		Query:  req.URL.Query().Encode(),
+		Header: r.scrubHeader(req.Header),
+		Body:   r.scrubBody(body),
+	}
+}
+
+func (r *Recorder) scrubHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+
+	out := h.Clone()
+	for _, key := range scrubbedHeaders {
+		out.Del(key)
+	}
+	for key, values := range out {
+		for i, v := range values {
+			out[key][i] = r.scrubSecrets(v)
+		}
+	}
+
+	return out
+}
+
+func (r *Recorder) scrubBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err == nil {
+		if scrubbed, err := json.Marshal(scrubJSON(doc)); err == nil {
+			body = scrubbed
+		}
+	}
+
+	return r.scrubSecrets(string(body))
+}
+
+func (r *Recorder) scrubSecrets(s string) string {
+	for _, secret := range r.cfg.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+
+	return s
+}
+
+func scrubJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if s, ok := value.(string); ok && sensitiveKeys[key] && s != "" {
+				t[key] = Redacted
+				continue
+			}
+			t[key] = scrubJSON(value)
+		}
+	case []interface{}:
+		for i, value := range t {
+			t[i] = scrubJSON(value)
+		}
+	}
+
+	return v
+}
+
+func matches(recorded, actual Request) bool {
+	return recorded.Method == actual.Method &&
+		recorded.Host == actual.Host &&
+		recorded.Path == actual.Path &&
+		recorded.Query == actual.Query &&
+		recorded.Body == actual.Body
+}
@@ -0,0 +1,100 @@
+package httpreplay
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"name":"bucket","shadow_bucket":{"access_key":"AKIDEXAMPLE","secret_key":"shh"}}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	rec, err := New(path, Config{Mode: ModeRecord, Secrets: []string{"AKIDEXAMPLE", "super-secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := doRequest(t, rec, server.URL+"/bucket?metadata=", "super-secret")
+	second := doRequest(t, rec, server.URL+"/bucket?metadata=", "super-secret")
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"AKIDEXAMPLE", "super-secret", "shh", "Authorization"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("fixture contains %q:\n%s", secret, data)
+		}
+	}
+
+	rep, err := New(path, Config{Mode: ModeReplay})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := doRequest(t, rep, server.URL+"/bucket?metadata=", "other-secret"); got.status != first.status {
+		t.Errorf("first replayed status = %d, want %d", got.status, first.status)
+	}
+	got := doRequest(t, rep, server.URL+"/bucket?metadata=", "other-secret")
+	if got.status != second.status {
+		t.Errorf("second replayed status = %d, want %d", got.status, second.status)
+	}
+	if !strings.Contains(got.body, `"secret_key":"REDACTED"`) {
+		t.Errorf("replayed body is not scrubbed: %s", got.body)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/bucket?metadata=", nil)
+	if _, err := rep.RoundTrip(req); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("expected ErrNoInteraction once fixture is exhausted, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("server received %d calls, want 2", calls)
+	}
+}
+
+type result struct {
+	status int
+	body   string
+}
+
+func doRequest(t *testing.T, rt http.RoundTripper, url, secret string) result {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+secret)
+	req.Header.Set("S3-Identity-Id", "AKIDEXAMPLE")
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result{status: resp.StatusCode, body: string(body)}
+}
@@ -0,0 +1,1247 @@
+// Package memory implements an in-memory Tigris backend. It serves the subset
+// of the S3 and bucket metadata APIs used by the provider from process memory,
+// so that the provider can be used without a Tigris account, for example with
+// `terraform test` or for local module development.
+package memory
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+// Endpoint is the endpoint the client is configured with when it is backed by
+// the in-memory backend. Requests to it never leave the process.
+const Endpoint = "http://memory.tigris.local"
+
+// IAMEndpoint is the IAM endpoint the client is configured with when it is
+// backed by the in-memory backend.
+const IAMEndpoint = "http://iam.memory.tigris.local"
+
+const (
+	headerAmzAcl               = "X-Amz-Acl"
+	headerAmzPublicListObjects = "X-Amz-Acl-Public-List-Objects-Enabled"
+	headerAmzStorageClass      = "X-Amz-Storage-Class"
+	headerTigrisEnableSnapshot = "X-Tigris-Enable-Snapshot"
+	headerTigrisSnapshot       = "X-Tigris-Snapshot"
+	headerTigrisSnapshotVer    = "X-Tigris-Snapshot-Version"
+	headerTigrisForkSource     = "X-Tigris-Fork-Source-Bucket"
+	headerTigrisForkSnapshot   = "X-Tigris-Fork-Source-Bucket-Snapshot"
+	headerAmzObjectLock        = "X-Amz-Bucket-Object-Lock-Enabled"
+
+	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	iamNamespace = "https://iam.amazonaws.com/doc/2010-05-08/"
+)
+
+type bucket struct {
+	metadata  types.BucketMetadata
+	createdAt time.Time
+	objects   map[string]*object
+	tags      map[string]string
+	snapshots []snapshot
+
+	// noncurrent holds the previous versions and delete markers of the
+	// objects, oldest first, while versioning is or has been enabled.
+	noncurrent map[string][]*object
+
+	// configs holds the documents of the bucket configuration subresources,
+	// keyed by their query parameter.
+	configs map[string][]byte
+
+	// uploads holds the keys of the multipart uploads in progress, keyed by
+	// upload ID.
+	uploads map[string]string
+}
+
+type snapshot struct {
+	name      string
+	version   int64
+	createdAt time.Time
+	objects   map[string]*object
+}
+
+type object struct {
+	data         []byte
+	contentType  string
+	etag         string
+	lastModified time.Time
+	versionID    string
+	deleteMarker bool
+}
+
+// Backend is an http.RoundTripper that serves Tigris API requests from memory.
+// It is safe for concurrent use. State lives as long as the Backend value.
+type Backend struct {
+	mu          sync.Mutex
+	host        string
+	iamHost     string
+	buckets     map[string]*bucket
+	lastVersion int64
+
+	// accessKeys holds the access keys managed through the IAM API.
+	accessKeys    map[string]*accessKey
+	lastAccessKey int64
+
+	// lastObjectVersion numbers the object versions of versioned buckets.
+	lastObjectVersion int64
+
+	// lastUploadID numbers the multipart uploads.
+	lastUploadID int64
+}
+
+// NewBackend returns an empty in-memory backend.
+func NewBackend() *Backend {
+	u, _ := url.Parse(Endpoint)
+	iamURL, _ := url.Parse(IAMEndpoint)
+
+	return &Backend{
+		host:       u.Host,
+		iamHost:    iamURL.Host,
+		buckets:    map[string]*bucket{},
+		accessKeys: map[string]*accessKey{},
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (b *Backend) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rec := &responseRecorder{header: http.Header{}}
+	if req.URL.Host == b.iamHost {
+		b.serveIAM(rec, body)
+		return rec.response(req), nil
+	}
+
+	bucketName, key := b.splitPath(req)
+	b.serve(rec, req, bucketName, key, body)
+
+	return rec.response(req), nil
+}
+
+func (b *Backend) serve(w *responseRecorder, req *http.Request, bucketName, key string, body []byte) {
+	query := req.URL.Query()
+
+	switch {
+	case bucketName == "":
+		if req.Method == http.MethodGet {
+			if v := req.Header.Get(headerTigrisSnapshot); v != "" {
+				b.listSnapshots(w, v)
+				return
+			}
+			b.listBuckets(w)
+			return
+		}
+	case key == "" && query.Has("tagging"):
+		b.bucketTagging(w, req, bucketName, body)
+		return
+	case key == "" && bucketConfigName(query) != "":
+		b.bucketConfig(w, req, bucketName, bucketConfigName(query), body)
+		return
+	case key == "":
+		switch req.Method {
+		case http.MethodPut:
+			if v := req.Header.Get(headerTigrisSnapshot); v != "" {
+				b.createSnapshot(w, bucketName, v)
+				return
+			}
+			b.createBucket(w, req, bucketName)
+			return
+		case http.MethodHead:
+			b.headBucket(w, bucketName)
+			return
+		case http.MethodDelete:
+			b.deleteBucket(w, bucketName)
+			return
+		case http.MethodPatch:
+			b.updateBucket(w, req, bucketName, body)
+			return
+		case http.MethodPost:
+			if query.Has("delete") {
+				b.deleteObjects(w, bucketName, body)
+				return
+			}
+		case http.MethodGet:
+			if query.Has("metadata") {
+				b.getBucketMetadata(w, bucketName)
+				return
+			}
+			if query.Has("versions") {
+				b.listObjectVersions(w, bucketName, query)
+				return
+			}
+			if query.Has("uploads") {
+				b.listMultipartUploads(w, bucketName)
+				return
+			}
+			b.listObjects(w, bucketName, query)
+			return
+		}
+	default:
+		switch req.Method {
+		case http.MethodPut:
+			b.putObject(w, req, bucketName, key, body)
+			return
+		case http.MethodGet, http.MethodHead:
+			b.getObject(w, req, bucketName, key)
+			return
+		case http.MethodPost:
+			if query.Has("uploads") {
+				b.createMultipartUpload(w, bucketName, key)
+				return
+			}
+		case http.MethodDelete:
+			if query.Has("uploadId") {
+				b.abortMultipartUpload(w, bucketName, query.Get("uploadId"))
+				return
+			}
+			b.deleteObject(w, bucketName, key, query.Get("versionId"))
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotImplemented, "NotImplemented", "The in-memory backend does not implement this operation.", bucketName)
+}
+
+// splitPath returns the bucket and object key addressed by the request,
+// supporting both virtual-hosted and path style addressing.
+func (b *Backend) splitPath(req *http.Request) (string, string) {
+	path := strings.TrimPrefix(req.URL.Path, "/")
+
+	host := req.URL.Hostname()
+	if strings.HasSuffix(host, "."+b.host) {
+		return strings.TrimSuffix(host, "."+b.host), path
+	}
+
+	bucketName, key, _ := strings.Cut(path, "/")
+
+	return bucketName, key
+}
+
+func (b *Backend) lookup(w *responseRecorder, bucketName string) *bucket {
+	bkt, ok := b.buckets[bucketName]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist", bucketName)
+		return nil
+	}
+
+	return bkt
+}
+
+func (b *Backend) listBuckets(w *responseRecorder) {
+	type bucketEntry struct {
+		Name         string `xml:"Name"`
+		CreationDate string `xml:"CreationDate"`
+	}
+	type result struct {
+		XMLName xml.Name      `xml:"ListAllMyBucketsResult"`
+		Xmlns   string        `xml:"xmlns,attr"`
+		Owner   struct{}      `xml:"Owner"`
+		Buckets []bucketEntry `xml:"Buckets>Bucket"`
+	}
+
+	out := result{Xmlns: s3Namespace}
+	for _, name := range sortedKeys(b.buckets) {
+		out.Buckets = append(out.Buckets, bucketEntry{
+			Name:         name,
+			CreationDate: b.buckets[name].createdAt.Format(time.RFC3339),
+		})
+	}
+
+	writeXML(w, http.StatusOK, out)
+}
+
+func (b *Backend) createBucket(w *responseRecorder, req *http.Request, bucketName string) {
+	if _, ok := b.buckets[bucketName]; ok {
+		writeError(w, http.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it.", bucketName)
+		return
+	}
+
+	bkt := &bucket{
+		metadata: types.BucketMetadata{
+			Name:         bucketName,
+			StorageClass: types.StorageClass(req.Header.Get(headerAmzStorageClass)),
+			Snapshot:     req.Header.Get(headerTigrisEnableSnapshot) == "true",
+		},
+		createdAt: time.Now().UTC(),
+		objects:   map[string]*object{},
+	}
+
+	// Forks share the immutable objects of their source.
+	if source := req.Header.Get(headerTigrisForkSource); source != "" {
+		objects, ok := b.forkObjects(w, source, req.Header.Get(headerTigrisForkSnapshot))
+		if !ok {
+			return
+		}
+
+		bkt.objects = objects
+		bkt.metadata.ForkSource = &types.BucketForkSource{
+			Bucket:   source,
+			Snapshot: req.Header.Get(headerTigrisForkSnapshot),
+		}
+	}
+
+	if req.Header.Get(headerAmzObjectLock) == "true" {
+		bkt.configs = map[string][]byte{
+			"object-lock": []byte(`<ObjectLockConfiguration xmlns="` + s3Namespace + `"><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`),
+		}
+	}
+
+	b.buckets[bucketName] = bkt
+
+	w.header.Set("Location", "/"+bucketName)
+	w.WriteHeader(http.StatusOK)
+}
+
+// createSnapshot takes a snapshot of the bucket. The header value has the
+// form "true" or "true; name=<name>". Object data is not copied.
+func (b *Backend) createSnapshot(w *responseRecorder, bucketName, header string) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+	if !bkt.metadata.Snapshot {
+		writeError(w, http.StatusBadRequest, "InvalidRequest", "Snapshots are not enabled for this bucket.", bucketName)
+		return
+	}
+
+	_, name, _ := strings.Cut(header, ";")
+	name = strings.TrimPrefix(strings.TrimSpace(name), "name=")
+
+	b.lastVersion = max(b.lastVersion+1, time.Now().UnixNano())
+	bkt.snapshots = append(bkt.snapshots, snapshot{
+		name:      name,
+		version:   b.lastVersion,
+		createdAt: time.Now().UTC(),
+		objects:   copyObjects(bkt.objects),
+	})
+
+	w.header.Set(headerTigrisSnapshotVer, strconv.FormatInt(b.lastVersion, 10))
+	w.WriteHeader(http.StatusOK)
+}
+
+// forkObjects returns the objects of the source bucket, or of one of its
+// snapshots when a version is given.
+func (b *Backend) forkObjects(w *responseRecorder, source, version string) (map[string]*object, bool) {
+	src, ok := b.buckets[source]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "The specified fork source bucket does not exist", source)
+		return nil, false
+	}
+	if version == "" {
+		return copyObjects(src.objects), true
+	}
+
+	for _, snap := range src.snapshots {
+		if strconv.FormatInt(snap.version, 10) == version {
+			return copyObjects(snap.objects), true
+		}
+	}
+
+	writeError(w, http.StatusBadRequest, "InvalidRequest", "The specified fork source snapshot does not exist", source)
+	return nil, false
+}
+
+// listSnapshots lists the snapshots of a bucket in the ListBuckets format.
+func (b *Backend) listSnapshots(w *responseRecorder, bucketName string) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	type bucketEntry struct {
+		Name         string `xml:"Name"`
+		CreationDate string `xml:"CreationDate"`
+	}
+	type result struct {
+		XMLName xml.Name      `xml:"ListAllMyBucketsResult"`
+		Xmlns   string        `xml:"xmlns,attr"`
+		Owner   struct{}      `xml:"Owner"`
+		Buckets []bucketEntry `xml:"Buckets>Bucket"`
+	}
+
+	out := result{Xmlns: s3Namespace}
+	for _, snap := range bkt.snapshots {
+		name := strconv.FormatInt(snap.version, 10)
+		if snap.name != "" {
+			name = fmt.Sprintf("%s; name=%s", name, snap.name)
+		}
+
+		out.Buckets = append(out.Buckets, bucketEntry{
+			Name:         name,
+			CreationDate: snap.createdAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	writeXML(w, http.StatusOK, out)
+}
+
+func (b *Backend) headBucket(w *responseRecorder, bucketName string) {
+	if _, ok := b.buckets[bucketName]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (b *Backend) deleteBucket(w *responseRecorder, bucketName string) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+	if len(bkt.objects) > 0 || len(bkt.noncurrent) > 0 {
+		writeError(w, http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty", bucketName)
+		return
+	}
+
+	delete(b.buckets, bucketName)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Backend) getBucketMetadata(w *responseRecorder, bucketName string) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, bkt.metadata)
+}
+
+func (b *Backend) updateBucket(w *responseRecorder, req *http.Request, bucketName string, body []byte) {
+	bkt, ok := b.buckets[bucketName]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, types.BucketUpdateResponse{
+			ErrorCode:    "NoSuchBucket",
+			ErrorMessage: "The specified bucket does not exist",
+		})
+		return
+	}
+
+	var upReq types.BucketUpdateRequest
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &upReq); err != nil {
+			writeJSON(w, http.StatusBadRequest, types.BucketUpdateResponse{
+				ErrorCode:    "MalformedJSON",
+				ErrorMessage: err.Error(),
+			})
+			return
+		}
+	}
+
+	if upReq.Website != nil {
+		bkt.metadata.Website = upReq.Website
+	}
+	if upReq.Shadow != nil {
+		bkt.metadata.Shadow = upReq.Shadow
+	}
+	if upReq.ObjectNotifications != nil {
+		bkt.metadata.ObjectNotifications = redactNotifications(upReq.ObjectNotifications)
+	}
+	if upReq.ObjectRegions != nil {
+		bkt.metadata.ObjectRegions = *upReq.ObjectRegions
+	}
+	if upReq.CacheControl != nil {
+		bkt.metadata.CacheControl = *upReq.CacheControl
+	}
+	if upReq.StorageClass != nil {
+		bkt.metadata.StorageClass = *upReq.StorageClass
+	}
+	if upReq.Consistency != nil {
+		bkt.metadata.Consistency = *upReq.Consistency
+	}
+
+	if v := req.Header.Get(headerAmzAcl); v != "" {
+		acl := types.BucketCannedACL(v)
+		bkt.md().ACL = &acl
+	}
+	if v := req.Header.Get(headerAmzPublicListObjects); v != "" {
+		bkt.md().PublicObjectsListEnabled = &v
+	}
+
+	writeJSON(w, http.StatusOK, types.BucketUpdateResponse{Update: "ok"})
+}
+
+// redactNotifications drops the webhook secrets, which the metadata API
+// never returns, and removes empty configurations.
+func redactNotifications(config *types.BucketNotificationConfig) *types.BucketNotificationConfig {
+	if config.WebhookURL == "" {
+		return nil
+	}
+
+	redacted := *config
+	if config.Auth != nil {
+		redacted.Auth = &types.BucketNotificationAuth{BasicUsername: config.Auth.BasicUsername}
+	}
+
+	return &redacted
+}
+
+func (bkt *bucket) md() *types.BucketMD {
+	if bkt.metadata.MD == nil {
+		bkt.metadata.MD = &types.BucketMD{}
+	}
+
+	return bkt.metadata.MD
+}
+
+type tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	TagSet  []tag    `xml:"TagSet>Tag"`
+}
+
+func (b *Backend) bucketTagging(w *responseRecorder, req *http.Request, bucketName string, body []byte) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		if len(bkt.tags) == 0 {
+			writeError(w, http.StatusNotFound, "NoSuchTagSet", "The TagSet does not exist", bucketName)
+			return
+		}
+
+		out := tagging{Xmlns: s3Namespace}
+		for _, key := range sortedKeys(bkt.tags) {
+			out.TagSet = append(out.TagSet, tag{Key: key, Value: bkt.tags[key]})
+		}
+		writeXML(w, http.StatusOK, out)
+	case http.MethodPut:
+		var in tagging
+		if err := xml.Unmarshal(body, &in); err != nil {
+			writeError(w, http.StatusBadRequest, "MalformedXML", err.Error(), bucketName)
+			return
+		}
+
+		bkt.tags = map[string]string{}
+		for _, t := range in.TagSet {
+			bkt.tags[t.Key] = t.Value
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		bkt.tags = nil
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.", bucketName)
+	}
+}
+
+// bucketConfigs maps the query parameters of the bucket configuration
+// subresources to the error code returned when the configuration is not set.
+var bucketConfigs = map[string]string{
+	"encryption":  "ServerSideEncryptionConfigurationNotFoundError",
+	"object-lock": "ObjectLockConfigurationNotFoundError",
+	"versioning":  "",
+	"logging":     "",
+	"cors":        "NoSuchCORSConfiguration",
+	"lifecycle":   "NoSuchLifecycleConfiguration",
+	"policy":      "NoSuchBucketPolicy",
+}
+
+// bucketConfigDefaults are returned instead of an error for the
+// configurations that always exist.
+var bucketConfigDefaults = map[string]string{
+	"versioning": `<VersioningConfiguration xmlns="` + s3Namespace + `"/>`,
+	"logging":    `<BucketLoggingStatus xmlns="` + s3Namespace + `"/>`,
+}
+
+func bucketConfigName(query url.Values) string {
+	for name := range bucketConfigs {
+		if query.Has(name) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// bucketConfig stores configuration documents as they are sent and returns
+// them unchanged, which matches the S3 API for these subresources.
+func (b *Backend) bucketConfig(w *responseRecorder, req *http.Request, bucketName, name string, body []byte) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		config, ok := bkt.configs[name]
+		if !ok && bucketConfigDefaults[name] != "" {
+			config, ok = []byte(bucketConfigDefaults[name]), true
+		}
+		if !ok {
+			writeError(w, http.StatusNotFound, bucketConfigs[name], fmt.Sprintf("The %s configuration does not exist", name), bucketName)
+			return
+		}
+
+		w.header.Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		w.body.Write(config)
+	case http.MethodPut:
+		if bkt.configs == nil {
+			bkt.configs = map[string][]byte{}
+		}
+		bkt.configs[name] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(bkt.configs, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.", bucketName)
+	}
+}
+
+func (b *Backend) putObject(w *responseRecorder, req *http.Request, bucketName, key string, body []byte) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	sum := md5.Sum(body)
+	obj := &object{
+		data:         body,
+		contentType:  req.Header.Get("Content-Type"),
+		etag:         `"` + hex.EncodeToString(sum[:]) + `"`,
+		lastModified: time.Now().UTC(),
+	}
+	b.putVersion(bkt, key, obj)
+
+	w.header.Set("ETag", obj.etag)
+	if obj.versionID != "null" {
+		w.header.Set("X-Amz-Version-Id", obj.versionID)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// putVersion makes obj the current version of the key. The previous version
+// is kept as a noncurrent version unless both are unversioned.
+func (b *Backend) putVersion(bkt *bucket, key string, obj *object) {
+	versioned := bkt.versioningEnabled()
+
+	obj.versionID = "null"
+	if versioned {
+		b.lastObjectVersion++
+		obj.versionID = strconv.FormatInt(b.lastObjectVersion, 10)
+	}
+
+	// An unversioned write replaces the current "null" version.
+	if current, ok := bkt.objects[key]; ok && (versioned || current.versionID != "null") {
+		bkt.pushNoncurrent(key, current)
+	}
+
+	if obj.deleteMarker {
+		delete(bkt.objects, key)
+		bkt.pushNoncurrent(key, obj)
+		return
+	}
+	bkt.objects[key] = obj
+}
+
+func (bkt *bucket) pushNoncurrent(key string, obj *object) {
+	if bkt.noncurrent == nil {
+		bkt.noncurrent = map[string][]*object{}
+	}
+	bkt.noncurrent[key] = append(bkt.noncurrent[key], obj)
+}
+
+// deleteVersion deletes the key the way a DeleteObject request does. Without
+// a version ID, versioned buckets get a delete marker instead.
+func (b *Backend) deleteVersion(bkt *bucket, key, versionID string) {
+	if versionID == "" {
+		if bkt.versioningEnabled() {
+			b.putVersion(bkt, key, &object{deleteMarker: true, lastModified: time.Now().UTC()})
+			return
+		}
+		versionID = "null"
+	}
+
+	if current, ok := bkt.objects[key]; ok && current.versionID == versionID {
+		delete(bkt.objects, key)
+		return
+	}
+
+	versions := bkt.noncurrent[key]
+	for i, v := range versions {
+		if v.versionID == versionID {
+			versions = append(versions[:i:i], versions[i+1:]...)
+			break
+		}
+	}
+	if len(versions) == 0 {
+		delete(bkt.noncurrent, key)
+		return
+	}
+	bkt.noncurrent[key] = versions
+}
+
+func (bkt *bucket) versioningEnabled() bool {
+	return bytes.Contains(bkt.configs["versioning"], []byte("<Status>Enabled</Status>"))
+}
+
+func (b *Backend) getObject(w *responseRecorder, req *http.Request, bucketName, key string) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	obj, ok := bkt.objects[key]
+	if !ok {
+		if req.Method == http.MethodHead {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeError(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.", bucketName)
+		return
+	}
+
+	w.header.Set("ETag", obj.etag)
+	w.header.Set("Last-Modified", obj.lastModified.Format(http.TimeFormat))
+	w.header.Set("Content-Length", strconv.Itoa(len(obj.data)))
+	if obj.contentType != "" {
+		w.header.Set("Content-Type", obj.contentType)
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if req.Method == http.MethodGet {
+		w.body.Write(obj.data)
+	}
+}
+
+func (b *Backend) deleteObject(w *responseRecorder, bucketName, key, versionID string) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	b.deleteVersion(bkt, key, versionID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Backend) deleteObjects(w *responseRecorder, bucketName string, body []byte) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	var in struct {
+		Objects []struct {
+			Key       string `xml:"Key"`
+			VersionId string `xml:"VersionId"`
+		} `xml:"Object"`
+	}
+	if err := xml.Unmarshal(body, &in); err != nil {
+		writeError(w, http.StatusBadRequest, "MalformedXML", err.Error(), bucketName)
+		return
+	}
+
+	type deleted struct {
+		Key       string `xml:"Key"`
+		VersionId string `xml:"VersionId,omitempty"`
+	}
+	type result struct {
+		XMLName xml.Name  `xml:"DeleteResult"`
+		Xmlns   string    `xml:"xmlns,attr"`
+		Deleted []deleted `xml:"Deleted"`
+	}
+
+	out := result{Xmlns: s3Namespace}
+	for _, o := range in.Objects {
+		b.deleteVersion(bkt, o.Key, o.VersionId)
+		out.Deleted = append(out.Deleted, deleted{Key: o.Key, VersionId: o.VersionId})
+	}
+
+	writeXML(w, http.StatusOK, out)
+}
+
+func (b *Backend) listObjects(w *responseRecorder, bucketName string, query url.Values) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	type content struct {
+		Key          string `xml:"Key"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+		Size         int    `xml:"Size"`
+		StorageClass string `xml:"StorageClass"`
+	}
+	type result struct {
+		XMLName               xml.Name  `xml:"ListBucketResult"`
+		Xmlns                 string    `xml:"xmlns,attr"`
+		Name                  string    `xml:"Name"`
+		Prefix                string    `xml:"Prefix"`
+		KeyCount              int       `xml:"KeyCount"`
+		MaxKeys               int       `xml:"MaxKeys"`
+		IsTruncated           bool      `xml:"IsTruncated"`
+		ContinuationToken     string    `xml:"ContinuationToken,omitempty"`
+		NextContinuationToken string    `xml:"NextContinuationToken,omitempty"`
+		Contents              []content `xml:"Contents"`
+	}
+
+	prefix := query.Get("prefix")
+	maxKeys := 1000
+	if v, err := strconv.Atoi(query.Get("max-keys")); err == nil && v > 0 && v < maxKeys {
+		maxKeys = v
+	}
+	token := query.Get("continuation-token")
+
+	out := result{
+		Xmlns:             s3Namespace,
+		Name:              bucketName,
+		Prefix:            prefix,
+		MaxKeys:           maxKeys,
+		ContinuationToken: token,
+	}
+	for _, key := range sortedKeys(bkt.objects) {
+		if !strings.HasPrefix(key, prefix) || (token != "" && key <= token) {
+			continue
+		}
+		if len(out.Contents) == maxKeys {
+			out.IsTruncated = true
+			out.NextContinuationToken = out.Contents[len(out.Contents)-1].Key
+			break
+		}
+
+		obj := bkt.objects[key]
+		out.Contents = append(out.Contents, content{
+			Key:          key,
+			LastModified: obj.lastModified.Format(time.RFC3339),
+			ETag:         obj.etag,
+			Size:         len(obj.data),
+			StorageClass: "STANDARD",
+		})
+	}
+	out.KeyCount = len(out.Contents)
+
+	writeXML(w, http.StatusOK, out)
+}
+
+// listObjectVersions lists the current and noncurrent versions and the delete
+// markers of the objects. Pages always end after all versions of a key.
+func (b *Backend) listObjectVersions(w *responseRecorder, bucketName string, query url.Values) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	type version struct {
+		Key          string `xml:"Key"`
+		VersionId    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+		Size         int    `xml:"Size"`
+		StorageClass string `xml:"StorageClass"`
+	}
+	type deleteMarker struct {
+		Key          string `xml:"Key"`
+		VersionId    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+	}
+	type result struct {
+		XMLName       xml.Name       `xml:"ListVersionsResult"`
+		Xmlns         string         `xml:"xmlns,attr"`
+		Name          string         `xml:"Name"`
+		Prefix        string         `xml:"Prefix"`
+		KeyMarker     string         `xml:"KeyMarker"`
+		NextKeyMarker string         `xml:"NextKeyMarker,omitempty"`
+		MaxKeys       int            `xml:"MaxKeys"`
+		IsTruncated   bool           `xml:"IsTruncated"`
+		Versions      []version      `xml:"Version"`
+		DeleteMarkers []deleteMarker `xml:"DeleteMarker"`
+	}
+
+	prefix := query.Get("prefix")
+	marker := query.Get("key-marker")
+	maxKeys := 1000
+	if v, err := strconv.Atoi(query.Get("max-keys")); err == nil && v > 0 && v < maxKeys {
+		maxKeys = v
+	}
+
+	keys := map[string]bool{}
+	for key := range bkt.objects {
+		keys[key] = true
+	}
+	for key := range bkt.noncurrent {
+		keys[key] = true
+	}
+
+	out := result{
+		Xmlns:     s3Namespace,
+		Name:      bucketName,
+		Prefix:    prefix,
+		KeyMarker: marker,
+		MaxKeys:   maxKeys,
+	}
+	count, lastKey := 0, ""
+	for _, key := range sortedKeys(keys) {
+		if !strings.HasPrefix(key, prefix) || (marker != "" && key <= marker) {
+			continue
+		}
+		if count >= maxKeys {
+			out.IsTruncated = true
+			out.NextKeyMarker = lastKey
+			break
+		}
+
+		// Newest first, like S3.
+		versions := append([]*object{}, bkt.noncurrent[key]...)
+		if obj, ok := bkt.objects[key]; ok {
+			versions = append(versions, obj)
+		}
+		for i := len(versions) - 1; i >= 0; i-- {
+			obj := versions[i]
+			latest := i == len(versions)-1
+			if obj.deleteMarker {
+				out.DeleteMarkers = append(out.DeleteMarkers, deleteMarker{
+					Key:          key,
+					VersionId:    obj.versionID,
+					IsLatest:     latest,
+					LastModified: obj.lastModified.Format(time.RFC3339),
+				})
+			} else {
+				out.Versions = append(out.Versions, version{
+					Key:          key,
+					VersionId:    obj.versionID,
+					IsLatest:     latest,
+					LastModified: obj.lastModified.Format(time.RFC3339),
+					ETag:         obj.etag,
+					Size:         len(obj.data),
+					StorageClass: "STANDARD",
+				})
+			}
+			count++
+		}
+		lastKey = key
+	}
+
+	writeXML(w, http.StatusOK, out)
+}
+
+// createMultipartUpload starts a multipart upload. Parts cannot be uploaded,
+// the upload can only be listed and aborted.
+func (b *Backend) createMultipartUpload(w *responseRecorder, bucketName, key string) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	b.lastUploadID++
+	uploadID := strconv.FormatInt(b.lastUploadID, 10)
+	if bkt.uploads == nil {
+		bkt.uploads = map[string]string{}
+	}
+	bkt.uploads[uploadID] = key
+
+	type result struct {
+		XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+		Xmlns    string   `xml:"xmlns,attr"`
+		Bucket   string   `xml:"Bucket"`
+		Key      string   `xml:"Key"`
+		UploadID string   `xml:"UploadId"`
+	}
+
+	writeXML(w, http.StatusOK, result{Xmlns: s3Namespace, Bucket: bucketName, Key: key, UploadID: uploadID})
+}
+
+func (b *Backend) abortMultipartUpload(w *responseRecorder, bucketName, uploadID string) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+	if _, ok := bkt.uploads[uploadID]; !ok {
+		writeError(w, http.StatusNotFound, "NoSuchUpload", "The specified multipart upload does not exist.", bucketName)
+		return
+	}
+
+	delete(bkt.uploads, uploadID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listMultipartUploads returns all uploads in progress in a single page.
+func (b *Backend) listMultipartUploads(w *responseRecorder, bucketName string) {
+	bkt := b.lookup(w, bucketName)
+	if bkt == nil {
+		return
+	}
+
+	type upload struct {
+		Key      string `xml:"Key"`
+		UploadID string `xml:"UploadId"`
+	}
+	type result struct {
+		XMLName     xml.Name `xml:"ListMultipartUploadsResult"`
+		Xmlns       string   `xml:"xmlns,attr"`
+		Bucket      string   `xml:"Bucket"`
+		IsTruncated bool     `xml:"IsTruncated"`
+		Uploads     []upload `xml:"Upload"`
+	}
+
+	out := result{Xmlns: s3Namespace, Bucket: bucketName}
+	for _, uploadID := range sortedKeys(bkt.uploads) {
+		out.Uploads = append(out.Uploads, upload{Key: bkt.uploads[uploadID], UploadID: uploadID})
+	}
+
+	writeXML(w, http.StatusOK, out)
+}
+
+type responseRecorder struct {
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *responseRecorder) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *responseRecorder) response(req *http.Request) *http.Response {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.header.Set("X-Amz-Request-Id", strconv.FormatInt(time.Now().UnixNano(), 10))
+
+	body := w.body.Bytes()
+	if req.Method == http.MethodHead {
+		body = nil
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", w.status, http.StatusText(w.status)),
+		StatusCode:    w.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        w.header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+type accessKey struct {
+	UserName        string    `xml:"UserName"`
+	AccessKeyID     string    `xml:"AccessKeyId"`
+	SecretAccessKey string    `xml:"SecretAccessKey,omitempty"`
+	Status          string    `xml:"Status"`
+	CreateDate      time.Time `xml:"CreateDate"`
+}
+
+// serveIAM serves the access key actions of the IAM query API.
+func (b *Backend) serveIAM(w *responseRecorder, body []byte) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeIAMError(w, http.StatusBadRequest, "MalformedInput", err.Error())
+		return
+	}
+
+	action := form.Get("Action")
+	switch action {
+	case "CreateAccessKey":
+		b.lastAccessKey++
+		id := fmt.Sprintf("tid_%016d", b.lastAccessKey)
+		key := &accessKey{
+			UserName:        form.Get("UserName"),
+			AccessKeyID:     id,
+			SecretAccessKey: fmt.Sprintf("tsec_%x", sha256.Sum256([]byte(id))),
+			Status:          "Active",
+			CreateDate:      time.Now().UTC().Truncate(time.Second),
+		}
+		b.accessKeys[id] = key
+
+		writeIAMResponse(w, action, struct {
+			AccessKey *accessKey `xml:"AccessKey"`
+		}{key})
+	case "GetAccessKeyLastUsed":
+		key, ok := b.accessKeys[form.Get("AccessKeyId")]
+		if !ok {
+			writeIAMError(w, http.StatusNotFound, "NoSuchEntity", "The Access Key with id "+form.Get("AccessKeyId")+" cannot be found.")
+			return
+		}
+
+		writeIAMResponse(w, action, struct {
+			UserName string `xml:"UserName"`
+		}{key.UserName})
+	case "ListAccessKeys":
+		// Like IAM, only the keys of the user are listed, the keys of the
+		// caller when the user name is omitted.
+		ids := make([]string, 0, len(b.accessKeys))
+		for id, key := range b.accessKeys {
+			if key.UserName == form.Get("UserName") {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+
+		keys := make([]accessKey, 0, len(ids))
+		for _, id := range ids {
+			key := *b.accessKeys[id]
+			key.SecretAccessKey = ""
+			keys = append(keys, key)
+		}
+
+		writeIAMResponse(w, action, struct {
+			AccessKeys  []accessKey `xml:"AccessKeyMetadata>member"`
+			IsTruncated bool        `xml:"IsTruncated"`
+		}{AccessKeys: keys})
+	case "UpdateAccessKey", "DeleteAccessKey":
+		key, ok := b.accessKeys[form.Get("AccessKeyId")]
+		if !ok {
+			writeIAMError(w, http.StatusNotFound, "NoSuchEntity", "The Access Key with id "+form.Get("AccessKeyId")+" cannot be found.")
+			return
+		}
+
+		if action == "DeleteAccessKey" {
+			delete(b.accessKeys, key.AccessKeyID)
+		} else {
+			status := form.Get("Status")
+			if status != "Active" && status != "Inactive" {
+				writeIAMError(w, http.StatusBadRequest, "ValidationError", "The status must be Active or Inactive.")
+				return
+			}
+			key.Status = status
+		}
+
+		writeIAMResponse(w, action, struct{}{})
+	default:
+		writeIAMError(w, http.StatusBadRequest, "InvalidAction", "The in-memory backend does not implement the action "+action+".")
+	}
+}
+
+func writeIAMResponse(w *responseRecorder, action string, result interface{}) {
+	type response struct {
+		XMLName xml.Name
+		Xmlns   string      `xml:"xmlns,attr"`
+		Result  interface{} `xml:"Result"`
+	}
+
+	data, err := xml.Marshal(response{
+		XMLName: xml.Name{Local: action + "Response"},
+		Xmlns:   iamNamespace,
+		Result:  result,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// The result element is named after the action.
+	data = bytes.Replace(data, []byte("<Result>"), []byte("<"+action+"Result>"), 1)
+	data = bytes.Replace(data, []byte("</Result>"), []byte("</"+action+"Result>"), 1)
+
+	w.header.Set("Content-Type", "text/xml")
+	w.WriteHeader(http.StatusOK)
+	w.body.WriteString(xml.Header)
+	w.body.Write(data)
+}
+
+func writeIAMError(w *responseRecorder, status int, code, message string) {
+	type errorResponse struct {
+		XMLName xml.Name `xml:"ErrorResponse"`
+		Xmlns   string   `xml:"xmlns,attr"`
+		Type    string   `xml:"Error>Type"`
+		Code    string   `xml:"Error>Code"`
+		Message string   `xml:"Error>Message"`
+	}
+
+	data, err := xml.Marshal(errorResponse{Xmlns: iamNamespace, Type: "Sender", Code: code, Message: message})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.header.Set("Content-Type", "text/xml")
+	w.WriteHeader(status)
+	w.body.WriteString(xml.Header)
+	w.body.Write(data)
+}
+
+func writeJSON(w *responseRecorder, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalError", err.Error(), "")
+		return
+	}
+
+	w.header.Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.body.Write(data)
+}
+
+func writeXML(w *responseRecorder, status int, v interface{}) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.header.Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.body.WriteString(xml.Header)
+	w.body.Write(data)
+}
+
+func writeError(w *responseRecorder, status int, code, message, bucketName string) {
+	type errorResponse struct {
+		XMLName    xml.Name `xml:"Error"`
+		Code       string   `xml:"Code"`
+		Message    string   `xml:"Message"`
+		BucketName string   `xml:"BucketName,omitempty"`
+	}
+
+	writeXML(w, status, errorResponse{Code: code, Message: message, BucketName: bucketName})
+}
+
+func copyObjects(objects map[string]*object) map[string]*object {
+	out := make(map[string]*object, len(objects))
+	for key, obj := range objects {
+		out[key] = obj
+	}
+
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
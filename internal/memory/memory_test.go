@@ -0,0 +1,158 @@
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/tigrisdata/terraform-provider-tigris/internal"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/memory"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func TestBackendBucketLifecycle(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+
+	client, err := internal.NewClient(memory.Endpoint, "memory", "memory", internal.WithTransport(backend))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-memory"}); err != nil {
+		t.Fatalf("unable to create bucket, %v", err)
+	}
+	if err := client.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-memory"}); err == nil || !strings.Contains(err.Error(), "BucketAlreadyOwnedByYou") {
+		t.Fatalf("expected BucketAlreadyOwnedByYou, got %v", err)
+	}
+
+	exists, err := client.HeadBucket(ctx, "tf-memory")
+	if err != nil || !exists {
+		t.Fatalf("expected bucket to exist, got exists=%t err=%v", exists, err)
+	}
+
+	acl := types.BucketCannedACLPublicRead
+	publicList := false
+	err = client.UpdateBucket(ctx, &types.BucketUpdateInput{
+		Bucket:                   "tf-memory",
+		ACL:                      &acl,
+		PublicObjectsListEnabled: &publicList,
+		Website:                  &types.BucketWebsiteConfig{DomainName: "assets.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unable to update bucket, %v", err)
+	}
+
+	metadata, err := client.GetBucketMetadata(ctx, "tf-memory")
+	if err != nil {
+		t.Fatalf("unable to read metadata, %v", err)
+	}
+	if metadata.GetBucketCannedACL() != acl || metadata.GetPublicObjectsListEnabled() {
+		t.Errorf("unexpected public access metadata: %+v", metadata.MD)
+	}
+	if metadata.Website == nil || metadata.Website.DomainName != "assets.example.com" {
+		t.Errorf("unexpected website metadata: %+v", metadata.Website)
+	}
+
+	if err := client.UpdateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-missing", ACL: &acl}); err == nil {
+		t.Error("expected update of missing bucket to fail")
+	}
+
+	if err := client.DeleteBucket(ctx, "tf-memory"); err != nil {
+		t.Fatalf("unable to delete bucket, %v", err)
+	}
+	exists, err = client.HeadBucket(ctx, "tf-memory")
+	if err != nil || exists {
+		t.Fatalf("expected bucket to be deleted, got exists=%t err=%v", exists, err)
+	}
+}
+
+func TestBackendObjects(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+
+	svc := s3.New(s3.Options{
+		BaseEndpoint: aws.String(memory.Endpoint),
+		Region:       "auto",
+		Credentials:  aws.AnonymousCredentials{},
+		HTTPClient:   &http.Client{Transport: backend},
+	})
+
+	if _, err := svc.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String("tf-objects")}); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"a.txt", "dir/b.txt", "dir/c.txt"} {
+		_, err := svc.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String("tf-objects"),
+			Key:    aws.String(key),
+			Body:   strings.NewReader("content of " + key),
+		})
+		if err != nil {
+			t.Fatalf("unable to put %s, %v", key, err)
+		}
+	}
+
+	list, err := svc.ListObjectsV2(ctx, &s3.ListObjectsV2Input{Bucket: aws.String("tf-objects"), Prefix: aws.String("dir/")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Contents) != 2 || aws.ToString(list.Contents[0].Key) != "dir/b.txt" {
+		t.Errorf("unexpected listing: %+v", list.Contents)
+	}
+
+	if _, err := svc.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String("tf-objects")}); err == nil || !strings.Contains(err.Error(), "BucketNotEmpty") {
+		t.Fatalf("expected BucketNotEmpty, got %v", err)
+	}
+
+	if _, err := svc.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String("tf-objects"), Key: aws.String("a.txt")}); err != nil {
+		t.Fatal(err)
+	}
+	list, err = svc.ListObjectsV2(ctx, &s3.ListObjectsV2Input{Bucket: aws.String("tf-objects")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aws.ToInt32(list.KeyCount) != 2 {
+		t.Errorf("expected 2 objects after delete, got %d", aws.ToInt32(list.KeyCount))
+	}
+}
+
+func TestBackendEmptyBucket(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.NewBackend()
+
+	client, err := internal.NewClient(memory.Endpoint, "memory", "memory", internal.WithTransport(backend))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := s3.New(s3.Options{
+		BaseEndpoint: aws.String(memory.Endpoint),
+		Region:       "auto",
+		Credentials:  aws.AnonymousCredentials{},
+		HTTPClient:   &http.Client{Transport: backend},
+	})
+
+	if err := client.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-empty"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2500; i++ {
+		_, err := svc.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String("tf-empty"),
+			Key:    aws.String(fmt.Sprintf("objects/%05d", i)),
+			Body:   strings.NewReader("x"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := client.EmptyBucket(ctx, "tf-empty"); err != nil {
+		t.Fatalf("unable to empty bucket, %v", err)
+	}
+	if err := client.DeleteBucket(ctx, "tf-empty"); err != nil {
+		t.Fatalf("unable to delete emptied bucket, %v", err)
+	}
+}
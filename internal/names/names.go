@@ -3,6 +3,59 @@ package names
 const (
 	// Attributes for the terraform resources.
 	AttrBucket             = "bucket"
+	AttrBucketPrefix       = "bucket_prefix"
+	AttrForceDestroy       = "force_destroy"
+	AttrDeletionProtection = "deletion_protection"
+	AttrAdoptExisting      = "adopt_existing"
+	AttrObjectRegions      = "object_regions"
+	AttrCacheControl       = "cache_control"
+	AttrStorageClass       = "storage_class"
+	AttrTags               = "tags"
+	AttrConsistency        = "consistency"
+	AttrEnableSnapshot     = "enable_snapshot"
+	AttrName               = "name"
+	AttrVersion            = "version"
+	AttrCreationDate       = "creation_date"
+	AttrSnapshots          = "snapshots"
+	AttrForkSourceBucket   = "fork_source_bucket"
+	AttrForkSourceSnapshot = "fork_source_snapshot"
+	AttrForkSource         = "fork_source"
+	AttrArn                = "arn"
+	AttrEndpointURL        = "endpoint_url"
+	AttrVirtualHostURL     = "virtual_host_url"
+	AttrS3URI              = "s3_uri"
+	AttrRegion             = "region"
+	AttrSSEAlgorithm       = "sse_algorithm"
+	AttrObjectLockEnabled  = "object_lock_enabled"
+	AttrDefaultRetention   = "default_retention"
+	AttrMode               = "mode"
+	AttrDays               = "days"
+	AttrYears              = "years"
+	AttrStatus             = "status"
+	AttrTargetBucket       = "target_bucket"
+	AttrTargetPrefix       = "target_prefix"
+	AttrCORSRule           = "cors_rule"
+	AttrAllowedMethods     = "allowed_methods"
+	AttrAllowedOrigins     = "allowed_origins"
+	AttrAllowedHeaders     = "allowed_headers"
+	AttrExposeHeaders      = "expose_headers"
+	AttrMaxAgeSeconds      = "max_age_seconds"
+	AttrRule               = "rule"
+	AttrID                 = "id"
+	AttrFilter             = "filter"
+	AttrPrefix             = "prefix"
+	AttrExpiration         = "expiration"
+	AttrDate               = "date"
+	AttrTransition         = "transition"
+	AttrWebhookURL         = "webhook_url"
+	AttrEvents             = "events"
+	AttrAuthToken          = "auth_token"
+	AttrAuthVersion        = "auth_version"
+	AttrBasicAuthUsername  = "basic_auth_username"
+	AttrBasicAuthPassword  = "basic_auth_password"
+	AttrPolicy             = "policy"
+	AttrAccessKeyID        = "access_key_id"
+	AttrSecretAccessKey    = "secret_access_key"
 	AttrAcl                = "acl"
 	AttrPublicListObjects  = "public_list_objects"
 	AttrDomainName         = "domain_name"
@@ -12,4 +65,12 @@ const (
 	AttrShadowBucket       = "shadow_bucket"
 	AttrShadowEndpoint     = "shadow_endpoint"
 	AttrShadowWriteThrough = "shadow_write_through"
+
+	// Attributes of the lifecycle rules.
+	AttrExpiredObjectDeleteMarker      = "expired_object_delete_marker"
+	AttrNoncurrentVersionExpiration    = "noncurrent_version_expiration"
+	AttrNoncurrentDays                 = "noncurrent_days"
+	AttrNewerNoncurrentVersions        = "newer_noncurrent_versions"
+	AttrAbortIncompleteMultipartUpload = "abort_incomplete_multipart_upload"
+	AttrDaysAfterInitiation            = "days_after_initiation"
 )
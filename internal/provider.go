@@ -5,8 +5,13 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/memory"
 )
 
+// memoryBackend holds the state of the in-memory backend. It is shared by all
+// provider instances and lives as long as the provider process.
+var memoryBackend = memory.NewBackend()
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -27,14 +32,51 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     DefaultEndpoint,
-				Description: "The endpoint for the Tigris object storage service.",
+				Description: fmt.Sprintf("The endpoint for the Tigris object storage service. Set it to `%s` to use an in-memory backend.", MemoryEndpoint),
+			},
+			"iam_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     DefaultIAMEndpoint,
+				Description: "The endpoint for the Tigris IAM service, used to manage access keys.",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     DefaultRegion,
+				Description: "The region requests are signed for. It is also the region to use with S3 clients.",
+			},
+			"mock": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to use an in-memory backend instead of the Tigris object storage service. State only lives as long as the provider process, which is useful for `terraform test` and local development.",
+			},
+			"adopt_existing_buckets": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether `tigris_bucket` resources take existing buckets you already own into state instead of failing to create them. It can be overridden with the `adopt_existing` attribute of the resource.",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"tigris_bucket":                resourceTigrisBucket(),
-			"tigris_bucket_public_access":  resourceTigrisBucketPublicAccess(),
-			"tigris_bucket_website_config": resourceTigrisBucketWebsiteConfig(),
-			"tigris_bucket_shadow_config":  resourceTigrisBucketShadowConfig(),
+			"tigris_bucket":                           resourceTigrisBucket(),
+			"tigris_bucket_public_access":             resourceTigrisBucketPublicAccess(),
+			"tigris_bucket_website_config":            resourceTigrisBucketWebsiteConfig(),
+			"tigris_bucket_shadow_config":             resourceTigrisBucketShadowConfig(),
+			"tigris_bucket_snapshot":                  resourceTigrisBucketSnapshot(),
+			"tigris_bucket_server_side_encryption":    resourceTigrisBucketServerSideEncryption(),
+			"tigris_bucket_object_lock_configuration": resourceTigrisBucketObjectLockConfiguration(),
+			"tigris_bucket_versioning":                resourceTigrisBucketVersioning(),
+			"tigris_bucket_logging":                   resourceTigrisBucketLogging(),
+			"tigris_bucket_cors_configuration":        resourceTigrisBucketCorsConfiguration(),
+			"tigris_bucket_lifecycle_configuration":   resourceTigrisBucketLifecycleConfiguration(),
+			"tigris_bucket_notification":              resourceTigrisBucketNotification(),
+			"tigris_bucket_policy":                    resourceTigrisBucketPolicy(),
+			"tigris_access_key":                       resourceTigrisAccessKey(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"tigris_bucket_snapshots": dataSourceTigrisBucketSnapshots(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
@@ -44,8 +86,34 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	accessKey := d.Get("access_key").(string)
 	secretKey := d.Get("secret_key").(string)
 	endpoint := d.Get("endpoint").(string)
+	iamEndpoint := d.Get("iam_endpoint").(string)
+
+	opts := []ClientOption{
+		WithAdoptExisting(d.Get("adopt_existing_buckets").(bool)),
+		WithRegion(d.Get("region").(string)),
+	}
+	if d.Get("mock").(bool) || endpoint == MemoryEndpoint {
+		// Buckets report the configured endpoint instead of the internal
+		// host of the in-memory backend.
+		opts = append(opts, WithEndpointURL(endpoint))
+
+		endpoint = memory.Endpoint
+		iamEndpoint = memory.IAMEndpoint
+		opts = append(opts, WithTransport(memoryBackend))
+
+		// The in-memory backend does not verify signatures, but the requests
+		// still need to be signed with some credentials.
+		if accessKey == "" {
+			accessKey = "memory"
+		}
+		if secretKey == "" {
+			secretKey = "memory"
+		}
+	}
+
+	opts = append(opts, WithIAMEndpoint(iamEndpoint))
 
-	svc, err := NewClient(endpoint, accessKey, secretKey)
+	svc, err := NewClient(endpoint, accessKey, secretKey, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load SDK config, %w", err)
 	}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/memory"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProviderConfigureMemory(t *testing.T) {
+	for _, raw := range []map[string]interface{}{
+		{"mock": true},
+		{"endpoint": MemoryEndpoint},
+	} {
+		p := Provider()
+		diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+		if diags.HasError() {
+			t.Fatalf("unable to configure provider with %v: %v", raw, diags)
+		}
+
+		svc := p.Meta().(*Client)
+		if err := svc.CreateBucket(context.Background(), &types.BucketUpdateInput{Bucket: "tf-provider-memory"}); err != nil {
+			t.Fatalf("unable to create bucket with %v: %v", raw, err)
+		}
+		if got := svc.EndpointURL(); got == memory.Endpoint {
+			t.Errorf("expected the configured endpoint with %v, got the in-memory backend host %q", raw, got)
+		}
+		if err := svc.DeleteBucket(context.Background(), "tf-provider-memory"); err != nil {
+			t.Fatalf("unable to delete bucket with %v: %v", raw, err)
+		}
+	}
+}
+
+func TestProviderConfigureRegion(t *testing.T) {
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"mock":   true,
+		"region": "iad",
+	}))
+	if diags.HasError() {
+		t.Fatalf("unable to configure provider: %v", diags)
+	}
+
+	svc := p.Meta().(*Client)
+	if got, want := svc.Region(), "iad"; got != want {
+		t.Errorf("Region() = %q, want %q", got, want)
+	}
+	if got, want := svc.EndpointURL(), DefaultEndpoint; got != want {
+		t.Errorf("EndpointURL() = %q, want %q", got, want)
+	}
+}
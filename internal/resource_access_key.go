@@ -0,0 +1,187 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func resourceTigrisAccessKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris access key resource. This can be used to create access keys, for example " +
+			"to hand to an application together with the buckets it uses.",
+		CreateWithoutTimeout: resourceAccessKeyCreate,
+		ReadWithoutTimeout:   resourceAccessKeyRead,
+		UpdateWithoutTimeout: resourceAccessKeyUpdate,
+		DeleteWithoutTimeout: resourceAccessKeyDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			names.AttrName: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The name of the access key.",
+			},
+			names.AttrStatus: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(types.AccessKeyStatusActive),
+				ValidateFunc: validation.StringInSlice(accessKeyStatus_Values(), false),
+				Description:  "The status of the access key, `Active` or `Inactive`. Inactive keys cannot be used to sign requests. Defaults to `Active`.",
+			},
+			names.AttrAccessKeyID: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the access key.",
+			},
+			names.AttrSecretAccessKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secret of the access key. It is only returned when the key is created, so it is not set for imported keys.",
+			},
+			names.AttrCreationDate: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the access key was created.",
+			},
+		},
+	}
+}
+
+func resourceAccessKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	name := d.Get(names.AttrName).(string)
+
+	tflog.Info(ctx, "Creating access key", map[string]interface{}{
+		"name": name,
+	})
+
+	key, err := svc.CreateAccessKey(ctx, name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create access key, %w", err))
+	}
+
+	tflog.Info(ctx, "Access key created successfully", map[string]interface{}{
+		"name":          name,
+		"access_key_id": key.AccessKeyID,
+	})
+
+	d.SetId(key.AccessKeyID)
+
+	// The secret is only returned when the key is created.
+	d.Set(names.AttrSecretAccessKey, key.SecretAccessKey)
+
+	if status := types.AccessKeyStatus(d.Get(names.AttrStatus).(string)); status != key.Status {
+		if err := svc.UpdateAccessKeyStatus(ctx, key.AccessKeyID, status); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to update access key status, %w", err))
+		}
+	}
+
+	return resourceAccessKeyRead(ctx, d, meta)
+}
+
+func resourceAccessKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	accessKeyID := d.Id()
+
+	tflog.Info(ctx, "Fetching access key", map[string]interface{}{
+		"access_key_id": accessKeyID,
+	})
+
+	key, err := svc.GetAccessKey(ctx, accessKeyID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read access key, %w", err))
+	}
+	if key == nil {
+		tflog.Warn(ctx, "Access key not found, removing from state", map[string]interface{}{
+			"access_key_id": accessKeyID,
+		})
+
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(names.AttrName, key.Name)
+	d.Set(names.AttrStatus, string(key.Status))
+	d.Set(names.AttrAccessKeyID, key.AccessKeyID)
+	d.Set(names.AttrCreationDate, key.CreateDate.Format(time.RFC3339))
+
+	return nil
+}
+
+func resourceAccessKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	accessKeyID := d.Id()
+
+	tflog.Info(ctx, "Updating access key", map[string]interface{}{
+		"access_key_id": accessKeyID,
+	})
+
+	if d.HasChange(names.AttrStatus) {
+		status := types.AccessKeyStatus(d.Get(names.AttrStatus).(string))
+		if err := svc.UpdateAccessKeyStatus(ctx, accessKeyID, status); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to update access key status, %w", err))
+		}
+	}
+
+	tflog.Info(ctx, "Access key updated successfully", map[string]interface{}{
+		"access_key_id": accessKeyID,
+	})
+
+	return resourceAccessKeyRead(ctx, d, meta)
+}
+
+func resourceAccessKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	accessKeyID := d.Id()
+
+	tflog.Info(ctx, "Deleting access key", map[string]interface{}{
+		"access_key_id": accessKeyID,
+	})
+
+	if err := svc.DeleteAccessKey(ctx, accessKeyID); err != nil && !isNoSuchEntity(err) {
+		return diag.FromErr(fmt.Errorf("unable to delete access key, %w", err))
+	}
+
+	tflog.Info(ctx, "Access key deleted successfully", map[string]interface{}{
+		"access_key_id": accessKeyID,
+	})
+
+	d.SetId("")
+	return nil
+}
+
+func accessKeyStatus_Values() []string {
+	var status types.AccessKeyStatus
+
+	values := []string{}
+	for _, value := range status.Values() {
+		values = append(values, string(value))
+	}
+
+	return values
+}
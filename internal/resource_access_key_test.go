@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func TestResourceAccessKey(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisAccessKey().Schema, map[string]interface{}{
+		names.AttrName:   "tf-app",
+		names.AttrStatus: "Inactive",
+	})
+	if diags := resourceAccessKeyCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create access key: %v", diags)
+	}
+	if id := d.Get(names.AttrAccessKeyID).(string); id == "" || id != d.Id() {
+		t.Errorf("access_key_id = %q, want the resource id %q", id, d.Id())
+	}
+	if secret := d.Get(names.AttrSecretAccessKey).(string); !strings.HasPrefix(secret, "tsec_") {
+		t.Errorf("unexpected secret_access_key %q", secret)
+	}
+	if status := d.Get(names.AttrStatus).(string); status != "Inactive" {
+		t.Errorf("status = %q, want %q", status, "Inactive")
+	}
+
+	// Imported keys are read back without their secret.
+	imported := resourceTigrisAccessKey().Data(nil)
+	imported.SetId(d.Id())
+	if diags := resourceAccessKeyRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to read access key: %v", diags)
+	}
+	if name := imported.Get(names.AttrName).(string); name != "tf-app" {
+		t.Errorf("name = %q, want %q", name, "tf-app")
+	}
+	if secret := imported.Get(names.AttrSecretAccessKey).(string); secret != "" {
+		t.Errorf("expected imported key without secret, got %q", secret)
+	}
+	if imported.Get(names.AttrCreationDate).(string) == "" {
+		t.Error("expected imported key to have a creation date")
+	}
+
+	// Changes made outside of Terraform are detected.
+	if err := svc.UpdateAccessKeyStatus(ctx, d.Id(), types.AccessKeyStatusActive); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceAccessKeyRead(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to read access key: %v", diags)
+	}
+	if status := d.Get(names.AttrStatus).(string); status != "Active" {
+		t.Errorf("status = %q, want %q", status, "Active")
+	}
+
+	if err := svc.DeleteAccessKey(ctx, d.Id()); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeleteAccessKey(ctx, d.Id()); !isNoSuchEntity(err) {
+		t.Errorf("expected NoSuchEntity deleting a deleted key, got %v", err)
+	}
+	if diags := resourceAccessKeyDelete(ctx, d, svc); diags.HasError() {
+		t.Errorf("unable to delete deleted access key: %v", diags)
+	}
+	if diags := resourceAccessKeyRead(ctx, imported, svc); diags.HasError() || imported.Id() != "" {
+		t.Errorf("expected deleted access key to be removed from state, got id=%q diags=%v", imported.Id(), diags)
+	}
+}
@@ -2,14 +2,17 @@ package internal
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/YakDriver/regexache"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
 	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
 )
@@ -33,11 +36,138 @@ func resourceTigrisBucket() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceBucketCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			names.AttrBucket: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{names.AttrBucket, names.AttrBucketPrefix},
+				Description:  "The name of the Tigris bucket. Changing it creates a new bucket and destroys the existing one. It is generated when `bucket_prefix` is set.",
+			},
+			names.AttrBucketPrefix: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{names.AttrBucket, names.AttrBucketPrefix},
+				ValidateFunc: validBucketPrefix,
+				Description:  "Creates a bucket with a unique name beginning with the prefix. Changing it creates a new bucket and destroys the existing one.",
+			},
+			names.AttrForceDestroy: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to delete all objects, including object versions and in-progress multipart uploads, from the bucket so that the bucket can be destroyed without error. Plans that replace a non-empty bucket fail unless it is set.",
+			},
+			names.AttrDeletionProtection: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to prevent the bucket from being destroyed or replaced. It has to be set to `false` in a separate apply before the bucket can be destroyed.",
+			},
+			names.AttrAdoptExisting: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to take an existing bucket you already own into state, and reconcile its configuration, instead of failing to create it. Defaults to the provider `adopt_existing_buckets` setting.",
+			},
+			names.AttrObjectRegions: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(objectRegion_Values(), false),
+				},
+				Description: "The regions to restrict the bucket objects to, for example for data residency. Objects are placed globally when it is not set.",
+			},
+			names.AttrCacheControl: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The default Cache-Control header for objects in the bucket, for example `public, max-age=3600`.",
+				ValidateFunc: validCacheControl,
+			},
+			names.AttrStorageClass: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(types.StorageClassStandard),
+				Description:  "The default storage class for objects in the bucket. Changing it to or from `GLACIER` creates a new bucket and destroys the existing one, other changes are made in place.",
+				ValidateFunc: validation.StringInSlice(storageClass_Values(), false),
+			},
+			names.AttrConsistency: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(types.ConsistencyDefault),
+				Description:  "The consistency mode of the bucket. `strict` makes reads strongly consistent across regions at the cost of latency, `default` favors low latency.",
+				ValidateFunc: validation.StringInSlice(consistency_Values(), false),
+			},
+			names.AttrTags: {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Description:  "The tags to assign to the bucket.",
+				ValidateFunc: validBucketTags,
+			},
+			names.AttrEnableSnapshot: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to enable snapshots for the bucket. It can only be set when the bucket is created.",
+			},
+			names.AttrObjectLockEnabled: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to enable object lock for the bucket, so that objects can be protected from being deleted or overwritten. It can only be set when the bucket is created and cannot be disabled.",
+			},
+			names.AttrForkSourceBucket: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of an existing bucket to create this bucket as a copy-on-write fork of.",
+			},
+			names.AttrForkSourceSnapshot: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{names.AttrForkSourceBucket},
+				Description:  "The snapshot version of the fork source bucket to fork from. The current state of the source bucket is forked when it is not set.",
+			},
+			names.AttrForkSource: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The bucket, as `<bucket>` or `<bucket>/<snapshot version>`, this bucket was forked from.",
+			},
+			names.AttrArn: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ARN of the bucket, for use in access policies.",
+			},
+			names.AttrEndpointURL: {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the Tigris bucket.",
+				Computed:    true,
+				Description: "The endpoint of the Tigris object storage service to use with S3 clients. It is the endpoint the provider is configured with.",
+			},
+			names.AttrVirtualHostURL: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The virtual-hosted style URL of the bucket.",
+			},
+			names.AttrS3URI: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The S3 URI of the bucket, in the form `s3://<bucket>`.",
+			},
+			names.AttrCreationDate: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the bucket was created, in RFC 3339 format.",
+			},
+			names.AttrRegion: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region to use with S3 clients. It is the region the provider is configured with.",
 			},
 		},
 	}
@@ -47,32 +177,214 @@ func resourceBucketCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	svc := meta.(*Client)
 
 	bucketName := d.Get(names.AttrBucket).(string)
-	if err := validBucketName(bucketName); err != nil {
-		return diag.FromErr(fmt.Errorf("invalid bucket name, %w", err))
+	bucketPrefix, generated := d.GetOk(names.AttrBucketPrefix)
+
+	input := &types.BucketUpdateInput{}
+
+	if v, ok := d.GetOk(names.AttrStorageClass); ok {
+		storageClass := types.StorageClass(v.(string))
+		input.StorageClass = &storageClass
 	}
 
-	input := &types.BucketUpdateInput{
-		Bucket: bucketName,
+	if d.Get(names.AttrEnableSnapshot).(bool) {
+		enableSnapshot := true
+		input.EnableSnapshot = &enableSnapshot
 	}
 
-	tflog.Info(ctx, "Creating bucket", map[string]interface{}{
-		"bucket_name": bucketName,
-	})
+	if d.Get(names.AttrObjectLockEnabled).(bool) {
+		objectLockEnabled := true
+		input.ObjectLockEnabled = &objectLockEnabled
+	}
 
-	err := svc.CreateBucket(ctx, input)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("unable to create bucket, %w", err))
+	if v, ok := d.GetOk(names.AttrForkSourceBucket); ok {
+		forkSourceBucket := v.(string)
+		input.ForkSourceBucket = &forkSourceBucket
+
+		if v, ok := d.GetOk(names.AttrForkSourceSnapshot); ok {
+			forkSourceSnapshot := v.(string)
+			input.ForkSourceSnapshot = &forkSourceSnapshot
+		}
 	}
 
-	tflog.Info(ctx, "Bucket created successfully", map[string]interface{}{
-		"bucket_name": bucketName,
-	})
+	var err error
+	for attempt := 1; ; attempt++ {
+		if generated {
+			bucketName, err = generateBucketName(bucketPrefix.(string))
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("unable to generate bucket name, %w", err))
+			}
+		}
+		if err := validBucketName(bucketName); err != nil {
+			return diag.FromErr(fmt.Errorf("invalid bucket name, %w", err))
+		}
+
+		tflog.Info(ctx, "Creating bucket", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		input.Bucket = bucketName
+		err = svc.CreateBucket(ctx, input)
+
+		// Pick another name when the generated one is already taken
+		if generated && attempt < bucketNameAttempts && (isBucketAlreadyExists(err) || isBucketAlreadyOwnedByYou(err)) {
+			tflog.Info(ctx, "Generated bucket name is taken, retrying", map[string]interface{}{
+				"bucket_name": bucketName,
+			})
+			continue
+		}
+		break
+	}
+
+	adopted := false
+
+	switch {
+	case err == nil:
+		tflog.Info(ctx, "Bucket created successfully", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+	case isBucketAlreadyOwnedByYou(err) && !generated && adoptExistingBucket(d, svc):
+		if err := validateBucketAdoption(ctx, d, svc, bucketName); err != nil {
+			return diag.FromErr(err)
+		}
+
+		tflog.Info(ctx, "Adopting existing bucket", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		adopted = true
+	case generated && (isBucketAlreadyExists(err) || isBucketAlreadyOwnedByYou(err)):
+		return diag.FromErr(fmt.Errorf("no unique bucket name with prefix %q after %d attempts, choose a different prefix", bucketPrefix, bucketNameAttempts))
+	case isBucketAlreadyOwnedByYou(err):
+		return diag.FromErr(fmt.Errorf("bucket %q already exists and is owned by you. Import it, or set %s = true to adopt it", bucketName, names.AttrAdoptExisting))
+	case isBucketAlreadyExists(err):
+		return diag.FromErr(fmt.Errorf("bucket name %q is already taken by another account, choose a different name", bucketName))
+	default:
+		return diag.FromErr(fmt.Errorf("unable to create bucket, %w", err))
+	}
 
 	d.SetId(bucketName)
 
+	upInput := &types.BucketUpdateInput{
+		Bucket: bucketName,
+	}
+	needsUpdate := false
+
+	// An adopted bucket keeps its existing configuration unless all of it is
+	// set again, including the attributes that are not configured.
+
+	//
+	// Bucket Object Regions.
+	//
+	if v, ok := d.GetOk(names.AttrObjectRegions); adopted || ok && v.(*schema.Set).Len() > 0 {
+		objectRegions := strings.Join(expandStringSet(d.Get(names.AttrObjectRegions).(*schema.Set)), ",")
+		upInput.ObjectRegions = &objectRegions
+
+		needsUpdate = true
+	}
+
+	//
+	// Bucket Cache Control.
+	//
+	if v, ok := d.GetOk(names.AttrCacheControl); adopted || ok {
+		cacheControl := v.(string)
+		upInput.CacheControl = &cacheControl
+
+		needsUpdate = true
+	}
+
+	//
+	// Bucket Storage Class.
+	//
+	if adopted && input.StorageClass != nil {
+		upInput.StorageClass = input.StorageClass
+
+		needsUpdate = true
+	}
+
+	//
+	// Bucket Consistency.
+	//
+	if v := types.Consistency(d.Get(names.AttrConsistency).(string)); adopted || v != types.ConsistencyDefault {
+		upInput.Consistency = &v
+
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		exists, err := svc.FindBucketWithRetry(ctx, bucketName)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to find created bucket, %w", err))
+		}
+		if !exists {
+			return diag.FromErr(fmt.Errorf("bucket %q not visible after create", bucketName))
+		}
+
+		tflog.Info(ctx, "Updating bucket metadata", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		if err := svc.UpdateBucket(ctx, upInput); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to update bucket metadata, %w", err))
+		}
+	}
+
+	//
+	// Bucket Tags.
+	//
+	if v, ok := d.GetOk(names.AttrTags); adopted || ok && len(v.(map[string]interface{})) > 0 {
+		tflog.Info(ctx, "Tagging bucket", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		if err := svc.UpdateBucketTags(ctx, bucketName, expandStringMap(v.(map[string]interface{}))); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to tag bucket, %w", err))
+		}
+	}
+
 	return resourceBucketRead(ctx, d, meta)
 }
 
+// adoptExistingBucket reports whether an existing bucket owned by the caller
+// is adopted, falling back to the provider default when it is not configured.
+func adoptExistingBucket(d *schema.ResourceData, svc *Client) bool {
+	// GetOk cannot tell an explicit false from an unset attribute.
+	if v, ok := d.GetOkExists(names.AttrAdoptExisting); ok {
+		return v.(bool)
+	}
+
+	return svc.AdoptExisting()
+}
+
+// validateBucketAdoption checks that the existing bucket can be adopted with
+// the configuration that is only applied when a bucket is created.
+func validateBucketAdoption(ctx context.Context, d *schema.ResourceData, svc *Client, bucketName string) error {
+	if v, ok := d.GetOk(names.AttrForkSourceBucket); ok {
+		return fmt.Errorf("unable to adopt existing bucket %q as a fork of %q, forks can only be created", bucketName, v.(string))
+	}
+
+	metadata, err := svc.GetBucketMetadata(ctx, bucketName)
+	if err != nil {
+		return fmt.Errorf("unable to read metadata of existing bucket %q, %w", bucketName, err)
+	}
+	if enable := d.Get(names.AttrEnableSnapshot).(bool); enable != metadata.Snapshot {
+		return fmt.Errorf("unable to adopt existing bucket %q, %s = %t does not match the bucket and can only be set when the bucket is created", bucketName, names.AttrEnableSnapshot, enable)
+	}
+
+	if storageClass := types.StorageClass(d.Get(names.AttrStorageClass).(string)); storageClassRequiresReplacement(metadata.GetStorageClass(), storageClass) {
+		return fmt.Errorf("unable to adopt existing bucket %q, %s = %q cannot be switched in place from %q", bucketName, names.AttrStorageClass, storageClass, metadata.GetStorageClass())
+	}
+
+	objectLock, err := svc.GetBucketObjectLock(ctx, bucketName)
+	if err != nil {
+		return fmt.Errorf("unable to read object lock configuration of existing bucket %q, %w", bucketName, err)
+	}
+	if enable := d.Get(names.AttrObjectLockEnabled).(bool); enable != objectLock.Enabled {
+		return fmt.Errorf("unable to adopt existing bucket %q, %s = %t does not match the bucket and can only be set when the bucket is created", bucketName, names.AttrObjectLockEnabled, enable)
+	}
+
+	return nil
+}
+
 func resourceBucketRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	svc := meta.(*Client)
 
@@ -96,12 +408,179 @@ func resourceBucketRead(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 
 	d.Set(names.AttrBucket, bucketName)
+	d.Set(names.AttrArn, fmt.Sprintf("arn:aws:s3:::%s", bucketName))
+	d.Set(names.AttrEndpointURL, svc.EndpointURL())
+	d.Set(names.AttrVirtualHostURL, svc.BucketVirtualHostURL(bucketName))
+	d.Set(names.AttrS3URI, fmt.Sprintf("s3://%s", bucketName))
+	d.Set(names.AttrRegion, svc.Region())
+
+	// Set the defaults for imported buckets, they are not stored remotely.
+	if _, ok := d.GetOk(names.AttrForceDestroy); !ok {
+		d.Set(names.AttrForceDestroy, false)
+	}
+	if _, ok := d.GetOk(names.AttrDeletionProtection); !ok {
+		d.Set(names.AttrDeletionProtection, false)
+	}
+
+	tflog.Info(ctx, "Fetching bucket metadata", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	metadata, err := svc.GetBucketMetadata(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket metadata, %w", err))
+	}
+
+	tflog.Info(ctx, "Fetched bucket metadata", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.Set(names.AttrObjectRegions, flattenStringSet(metadata.GetObjectRegions()))
+	d.Set(names.AttrCacheControl, metadata.CacheControl)
+	d.Set(names.AttrStorageClass, string(metadata.GetStorageClass()))
+	d.Set(names.AttrConsistency, string(metadata.GetConsistency()))
+	d.Set(names.AttrEnableSnapshot, metadata.Snapshot)
+
+	forkSource := ""
+	if metadata.ForkSource != nil && metadata.ForkSource.Bucket != "" {
+		forkSource = metadata.ForkSource.Bucket
+		if metadata.ForkSource.Snapshot != "" {
+			forkSource = bucketSnapshotID(metadata.ForkSource.Bucket, metadata.ForkSource.Snapshot)
+		}
+	}
+	d.Set(names.AttrForkSource, forkSource)
+
+	// Endpoints without object lock support keep the value from the state,
+	// rather than reporting the lock as disabled.
+	objectLock, err := svc.GetBucketObjectLock(ctx, bucketName)
+	switch {
+	case isUnsupportedOperation(err):
+		tflog.Warn(ctx, "Object lock is not supported by the endpoint, keeping object_lock_enabled from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("unable to read bucket object lock configuration, %w", err))
+	default:
+		d.Set(names.AttrObjectLockEnabled, objectLock.Enabled)
+	}
+
+	// The creation date is only in the bucket listing, so it is looked up
+	// once when the bucket is created or imported and kept from the state.
+	if _, ok := d.GetOk(names.AttrCreationDate); !ok {
+		creationDate, found, err := svc.GetBucketCreationDate(ctx, bucketName)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to list buckets, %w", err))
+		}
+		if found {
+			d.Set(names.AttrCreationDate, creationDate.Format(time.RFC3339))
+		}
+	}
+
+	tflog.Info(ctx, "Fetching bucket tags", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	tags, err := svc.GetBucketTags(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket tags, %w", err))
+	}
+
+	d.Set(names.AttrTags, tags)
 
 	return nil
 }
 
 func resourceBucketUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// This resource cannot be updated
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	input := &types.BucketUpdateInput{
+		Bucket: bucketName,
+	}
+	needsUpdate := false
+
+	tflog.Info(ctx, "Updating bucket", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	//
+	// Bucket Object Regions.
+	//
+	if d.HasChange(names.AttrObjectRegions) {
+		objectRegions := strings.Join(expandStringSet(d.Get(names.AttrObjectRegions).(*schema.Set)), ",")
+		input.ObjectRegions = &objectRegions
+
+		tflog.Info(ctx, "Will update bucket object regions", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		needsUpdate = true
+	}
+
+	//
+	// Bucket Cache Control.
+	//
+	if d.HasChange(names.AttrCacheControl) {
+		cacheControl := d.Get(names.AttrCacheControl).(string)
+		input.CacheControl = &cacheControl
+
+		tflog.Info(ctx, "Will update bucket cache control", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		needsUpdate = true
+	}
+
+	//
+	// Bucket Storage Class.
+	//
+	if d.HasChange(names.AttrStorageClass) {
+		storageClass := types.StorageClass(d.Get(names.AttrStorageClass).(string))
+		input.StorageClass = &storageClass
+
+		tflog.Info(ctx, "Will update bucket storage class", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		needsUpdate = true
+	}
+
+	//
+	// Bucket Consistency.
+	//
+	if d.HasChange(names.AttrConsistency) {
+		consistency := types.Consistency(d.Get(names.AttrConsistency).(string))
+		input.Consistency = &consistency
+
+		tflog.Info(ctx, "Will update bucket consistency", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		err := svc.UpdateBucket(ctx, input)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to update bucket, %w", err))
+		}
+	}
+
+	//
+	// Bucket Tags.
+	//
+	if d.HasChange(names.AttrTags) {
+		tflog.Info(ctx, "Updating bucket tags", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		err := svc.UpdateBucketTags(ctx, bucketName, expandStringMap(d.Get(names.AttrTags).(map[string]interface{})))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to update bucket tags, %w", err))
+		}
+	}
+
 	return resourceBucketRead(ctx, d, meta)
 }
 
@@ -110,6 +589,31 @@ func resourceBucketDelete(ctx context.Context, d *schema.ResourceData, meta inte
 
 	bucketName := d.Id()
 
+	if d.Get(names.AttrDeletionProtection).(bool) {
+		return diag.FromErr(deletionProtectionError(bucketName))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	if d.Get(names.AttrForceDestroy).(bool) {
+		tflog.Info(ctx, "Emptying bucket before deletion", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		if err := svc.EmptyBucket(ctx, bucketName); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to empty bucket, %w", err))
+		}
+
+		tflog.Info(ctx, "Bucket emptied successfully", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+	}
+
+	tflog.Info(ctx, "Deleting bucket", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
 	err := svc.DeleteBucket(ctx, bucketName)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("unable to delete bucket, %w", err))
@@ -119,6 +623,150 @@ func resourceBucketDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	return nil
 }
 
+func resourceBucketCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	svc := meta.(*Client)
+
+	// The fork source is only used when the bucket is created
+	if d.Id() == "" || d.HasChanges(names.AttrForkSourceBucket, names.AttrForkSourceSnapshot) {
+		if err := validateForkSource(ctx, d, svc); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	// The bucket is created with a new generated name
+	if d.HasChange(names.AttrBucketPrefix) && d.Get(names.AttrBucketPrefix).(string) != "" {
+		if err := d.SetNewComputed(names.AttrBucket); err != nil {
+			return err
+		}
+	}
+
+	// Buckets cannot be renamed, a new bucket has to be created instead
+	if d.HasChange(names.AttrBucket) {
+		if err := d.ForceNew(names.AttrBucket); err != nil {
+			return err
+		}
+	}
+
+	// Object lock cannot be disabled, replacing the bucket would destroy the
+	// objects it protects
+	if o, n := d.GetChange(names.AttrObjectLockEnabled); o.(bool) && !n.(bool) {
+		return fmt.Errorf("object lock cannot be disabled once it is enabled on bucket %q", d.Id())
+	}
+
+	var changed []string
+	for _, attr := range bucketReplacingAttrs {
+		if d.HasChange(attr) {
+			changed = append(changed, attr)
+		}
+	}
+
+	// The archive tier cannot be switched in place
+	if o, n := d.GetChange(names.AttrStorageClass); storageClassRequiresReplacement(types.StorageClass(o.(string)), types.StorageClass(n.(string))) {
+		if err := d.ForceNew(names.AttrStorageClass); err != nil {
+			return err
+		}
+
+		changed = append(changed, names.AttrStorageClass)
+	}
+
+	if len(changed) > 0 {
+		return validateBucketReplacement(ctx, d, svc, changed)
+	}
+
+	return nil
+}
+
+// storageClassRequiresReplacement reports whether changing the default
+// storage class of a bucket replaces the bucket. Objects in the GLACIER
+// archive tier have to be restored before they can be read, so Tigris does
+// not switch a bucket to or from it in place.
+func storageClassRequiresReplacement(old, new types.StorageClass) bool {
+	return old != new && (old == types.StorageClassGlacier || new == types.StorageClassGlacier)
+}
+
+// bucketReplacingAttrs are the attributes that replace the bucket when they
+// change.
+var bucketReplacingAttrs = []string{
+	names.AttrBucket,
+	names.AttrBucketPrefix,
+	names.AttrEnableSnapshot,
+	names.AttrObjectLockEnabled,
+	names.AttrForkSourceBucket,
+	names.AttrForkSourceSnapshot,
+}
+
+// validateBucketReplacement fails the plan when replacing the bucket for the
+// changed attributes would destroy a non-empty bucket that does not have
+// force_destroy set.
+func validateBucketReplacement(ctx context.Context, d *schema.ResourceDiff, svc *Client, changed []string) error {
+	bucketName := d.Id()
+
+	// Like force_destroy, the protection of the existing bucket applies.
+	if protected, _ := d.GetChange(names.AttrDeletionProtection); protected.(bool) {
+		return deletionProtectionError(bucketName)
+	}
+
+	// The existing bucket is destroyed with its current state, so the
+	// force_destroy value from the state applies.
+	forceDestroy, _ := d.GetChange(names.AttrForceDestroy)
+	if forceDestroy.(bool) {
+		return nil
+	}
+
+	empty, err := svc.IsBucketEmpty(ctx, bucketName)
+	if err != nil {
+		return fmt.Errorf("unable to check whether bucket %q is empty, %w", bucketName, err)
+	}
+	if empty {
+		return nil
+	}
+
+	return fmt.Errorf("changing %s replaces bucket %q, which is not empty and would be destroyed with all its objects. "+
+		"Empty the bucket first, or set %s = true and apply it before this change", strings.Join(changed, ", "), bucketName, names.AttrForceDestroy)
+}
+
+// validateForkSource checks that the fork source bucket, and snapshot if one
+// is given, exist before the bucket is created.
+func validateForkSource(ctx context.Context, d *schema.ResourceDiff, svc *Client) error {
+	if !d.NewValueKnown(names.AttrForkSourceBucket) || !d.NewValueKnown(names.AttrForkSourceSnapshot) {
+		return nil
+	}
+
+	source := d.Get(names.AttrForkSourceBucket).(string)
+	if source == "" {
+		return nil
+	}
+
+	exists, err := svc.HeadBucket(ctx, source)
+	if err != nil {
+		return fmt.Errorf("unable to check fork source bucket %q, %w", source, err)
+	}
+	if !exists {
+		return fmt.Errorf("fork source bucket %q does not exist", source)
+	}
+
+	version := d.Get(names.AttrForkSourceSnapshot).(string)
+	if version == "" {
+		return nil
+	}
+
+	snapshots, err := svc.ListBucketSnapshots(ctx, source)
+	if err != nil {
+		return fmt.Errorf("unable to list snapshots of fork source bucket %q, %w", source, err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Version == version {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("snapshot %q of fork source bucket %q does not exist", version, source)
+}
+
 // validBucketName validates bucket name. Buckets names have to be DNS-compliant.
 func validBucketName(value string) error {
 	if (len(value) < 3) || (len(value) > 63) {
@@ -142,3 +790,193 @@ func validBucketName(value string) error {
 
 	return nil
 }
+
+const (
+	// bucketNameSuffixLength is the length of the random suffix appended to
+	// the bucket prefix.
+	bucketNameSuffixLength = 10
+
+	// bucketNameAttempts is the number of generated names tried before
+	// giving up on name collisions.
+	bucketNameAttempts = 5
+)
+
+// generateBucketName returns the prefix followed by a random suffix of
+// lowercase alphanumeric characters.
+func generateBucketName(prefix string) (string, error) {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+	suffix := make([]byte, bucketNameSuffixLength)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	for i, b := range suffix {
+		suffix[i] = alphabet[int(b)%len(alphabet)]
+	}
+
+	return prefix + string(suffix), nil
+}
+
+// validBucketPrefix validates the bucket prefix so that the generated names
+// pass validBucketName.
+func validBucketPrefix(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if len(value) == 0 || len(value) > 63-bucketNameSuffixLength {
+		errors = append(errors, fmt.Errorf("%q must contain from 1 to %d characters", k, 63-bucketNameSuffixLength))
+	}
+	if !regexache.MustCompile(`^[0-9a-z-.]*$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("only lowercase alphanumeric characters, hyphens and periods allowed in %q", k))
+	}
+	if strings.HasPrefix(value, `.`) {
+		errors = append(errors, fmt.Errorf("%q cannot start with a period", k))
+	}
+	if strings.Contains(value, `..`) {
+		errors = append(errors, fmt.Errorf("%q can be only one period between labels", k))
+	}
+
+	return
+}
+
+// validBucketTags validates the bucket tags against the S3 tagging limits.
+func validBucketTags(v interface{}, k string) (ws []string, errors []error) {
+	tags := v.(map[string]interface{})
+
+	if len(tags) > 50 {
+		errors = append(errors, fmt.Errorf("%q can contain at most 50 tags, got %d", k, len(tags)))
+	}
+
+	for key, value := range tags {
+		if n := utf8.RuneCountInString(key); n < 1 || n > 128 {
+			errors = append(errors, fmt.Errorf("%q key %q must contain from 1 to 128 characters", k, key))
+		}
+		if strings.HasPrefix(strings.ToLower(key), "aws:") {
+			errors = append(errors, fmt.Errorf("%q key %q cannot start with the reserved prefix \"aws:\"", k, key))
+		}
+
+		if s, _ := value.(string); utf8.RuneCountInString(s) > 256 {
+			errors = append(errors, fmt.Errorf("%q value for key %q must contain at most 256 characters", k, key))
+		}
+	}
+
+	return ws, errors
+}
+
+// cacheControlDirectives maps the Cache-Control response directives to whether
+// they take a delta-seconds value.
+var cacheControlDirectives = map[string]bool{
+	"max-age":                true,
+	"s-maxage":               true,
+	"stale-while-revalidate": true,
+	"stale-if-error":         true,
+	"no-cache":               false,
+	"no-store":               false,
+	"no-transform":           false,
+	"must-revalidate":        false,
+	"proxy-revalidate":       false,
+	"must-understand":        false,
+	"private":                false,
+	"public":                 false,
+	"immutable":              false,
+}
+
+// validCacheControl validates the syntax of a Cache-Control header value.
+func validCacheControl(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	seen := map[string]bool{}
+	for _, directive := range splitCacheControl(value) {
+		directive = strings.TrimSpace(directive)
+		if directive == "" {
+			errors = append(errors, fmt.Errorf("%q contains an empty directive in %q", k, value))
+			continue
+		}
+
+		name, arg, hasArg := strings.Cut(directive, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		takesSeconds, ok := cacheControlDirectives[name]
+		if !ok {
+			errors = append(errors, fmt.Errorf("%q contains unknown directive %q", k, name))
+			continue
+		}
+		if seen[name] {
+			errors = append(errors, fmt.Errorf("%q contains duplicate directive %q", k, name))
+			continue
+		}
+		seen[name] = true
+
+		switch {
+		case takesSeconds && !hasArg:
+			errors = append(errors, fmt.Errorf("%q directive %q requires a value in seconds", k, name))
+		case takesSeconds && !regexache.MustCompile(`^[0-9]+$`).MatchString(strings.TrimSpace(arg)):
+			errors = append(errors, fmt.Errorf("%q directive %q must be a non-negative number of seconds, got %q", k, name, arg))
+		case !takesSeconds && hasArg && name != "no-cache" && name != "private":
+			errors = append(errors, fmt.Errorf("%q directive %q does not take a value", k, name))
+		case !takesSeconds && hasArg && !regexache.MustCompile(`^"[^"]*"$`).MatchString(strings.TrimSpace(arg)):
+			errors = append(errors, fmt.Errorf("%q directive %q value must be a quoted list of header names", k, name))
+		}
+	}
+
+	if seen["public"] && seen["private"] {
+		errors = append(errors, fmt.Errorf("%q cannot contain both %q and %q", k, "public", "private"))
+	}
+
+	return ws, errors
+}
+
+// splitCacheControl splits a Cache-Control value into directives, keeping
+// commas inside quoted arguments.
+func splitCacheControl(value string) []string {
+	var directives []string
+
+	start, quoted := 0, false
+	for i, c := range value {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			directives = append(directives, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(directives, value[start:])
+}
+
+func objectRegion_Values() []string {
+	var region types.ObjectRegion
+
+	values := []string{}
+	for _, value := range region.Values() {
+		values = append(values, string(value))
+	}
+
+	return values
+}
+
+func consistency_Values() []string {
+	var consistency types.Consistency
+
+	values := []string{}
+	for _, value := range consistency.Values() {
+		values = append(values, string(value))
+	}
+
+	return values
+}
+
+func storageClass_Values() []string {
+	var storageClass types.StorageClass
+
+	values := []string{}
+	for _, value := range storageClass.Values() {
+		values = append(values, string(value))
+	}
+
+	return values
+}
+
+func deletionProtectionError(bucketName string) error {
+	return fmt.Errorf("bucket %q has deletion protection enabled. Set %s = false and apply it before destroying or replacing the bucket", bucketName, names.AttrDeletionProtection)
+}
@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+// corsAllowedMethods are the HTTP methods a CORS rule can allow.
+var corsAllowedMethods = []string{"GET", "PUT", "POST", "DELETE", "HEAD"}
+
+func resourceTigrisBucketCorsConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris bucket CORS configuration resource. This can be used to allow browsers to make " +
+			"cross-origin requests to a bucket.",
+		CreateWithoutTimeout: resourceBucketCorsConfigurationCreate,
+		ReadWithoutTimeout:   resourceBucketCorsConfigurationRead,
+		UpdateWithoutTimeout: resourceBucketCorsConfigurationUpdate,
+		DeleteWithoutTimeout: resourceBucketCorsConfigurationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Tigris bucket.",
+			},
+			names.AttrCORSRule: {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    100,
+				Description: "The CORS rules of the bucket. The order of the rules is not significant.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						names.AttrAllowedMethods: {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(corsAllowedMethods, false),
+							},
+							Description: "The HTTP methods the origins are allowed to use, one of `GET`, `PUT`, `POST`, `DELETE` or `HEAD`.",
+						},
+						names.AttrAllowedOrigins: {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The origins allowed to make cross-origin requests, for example `https://example.com` or `*`.",
+						},
+						names.AttrAllowedHeaders: {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The headers allowed in preflight requests.",
+						},
+						names.AttrExposeHeaders: {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The response headers the browser is allowed to access.",
+						},
+						names.AttrMaxAgeSeconds: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "How long the browser can cache the preflight response, in seconds.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBucketCorsConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+
+	tflog.Info(ctx, "Creating bucket CORS configuration", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketCors(ctx, bucketName, expandCORSRules(d.Get(names.AttrCORSRule).(*schema.Set))); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create bucket CORS configuration, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket CORS configuration created successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId(bucketName)
+
+	return resourceBucketCorsConfigurationRead(ctx, d, meta)
+}
+
+func resourceBucketCorsConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Checking bucket existence", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if !exists {
+		tflog.Warn(ctx, "Bucket not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+
+	d.Set(names.AttrBucket, bucketName)
+
+	rules, err := svc.GetBucketCors(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket CORS configuration, %w", err))
+	}
+	if len(rules) == 0 {
+		tflog.Warn(ctx, "Bucket CORS configuration not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(names.AttrCORSRule, flattenCORSRules(rules)); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to set %s, %w", names.AttrCORSRule, err))
+	}
+
+	return nil
+}
+
+func resourceBucketCorsConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Updating bucket CORS configuration", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketCors(ctx, bucketName, expandCORSRules(d.Get(names.AttrCORSRule).(*schema.Set))); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to update bucket CORS configuration, %w", err))
+	}
+
+	return resourceBucketCorsConfigurationRead(ctx, d, meta)
+}
+
+func resourceBucketCorsConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Deleting bucket CORS configuration", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.DeleteBucketCors(ctx, bucketName); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to delete bucket CORS configuration, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket CORS configuration deleted successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId("")
+	return nil
+}
+
+func expandCORSRules(set *schema.Set) []types.CORSRule {
+	rules := make([]types.CORSRule, 0, set.Len())
+	for _, v := range set.List() {
+		m := v.(map[string]interface{})
+		rules = append(rules, types.CORSRule{
+			AllowedMethods: expandStringSet(m[names.AttrAllowedMethods].(*schema.Set)),
+			AllowedOrigins: expandStringSet(m[names.AttrAllowedOrigins].(*schema.Set)),
+			AllowedHeaders: expandStringSet(m[names.AttrAllowedHeaders].(*schema.Set)),
+			ExposeHeaders:  expandStringSet(m[names.AttrExposeHeaders].(*schema.Set)),
+			MaxAgeSeconds:  int32(m[names.AttrMaxAgeSeconds].(int)),
+		})
+	}
+
+	return rules
+}
+
+func flattenCORSRules(rules []types.CORSRule) []interface{} {
+	values := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		values = append(values, map[string]interface{}{
+			names.AttrAllowedMethods: flattenStringSet(rule.AllowedMethods),
+			names.AttrAllowedOrigins: flattenStringSet(rule.AllowedOrigins),
+			names.AttrAllowedHeaders: flattenStringSet(rule.AllowedHeaders),
+			names.AttrExposeHeaders:  flattenStringSet(rule.ExposeHeaders),
+			names.AttrMaxAgeSeconds:  int(rule.MaxAgeSeconds),
+		})
+	}
+
+	return values
+}
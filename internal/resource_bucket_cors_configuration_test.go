@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func TestResourceBucketCorsConfiguration(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	if err := svc.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-uploads"}); err != nil {
+		t.Fatal(err)
+	}
+
+	rule := func(origins ...string) map[string]interface{} {
+		return map[string]interface{}{
+			names.AttrAllowedMethods: []interface{}{"PUT", "GET"},
+			names.AttrAllowedOrigins: toInterfaceSlice(origins),
+			names.AttrAllowedHeaders: []interface{}{"Content-Type", "Authorization"},
+			names.AttrExposeHeaders:  []interface{}{"ETag"},
+			names.AttrMaxAgeSeconds:  3600,
+		}
+	}
+	raw := map[string]interface{}{
+		names.AttrBucket:   "tf-uploads",
+		names.AttrCORSRule: []interface{}{rule("https://example.com"), rule("https://app.example.com", "http://localhost:3000")},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucketCorsConfiguration().Schema, raw)
+	if diags := resourceBucketCorsConfigurationCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create CORS configuration: %v", diags)
+	}
+
+	imported := resourceTigrisBucketCorsConfiguration().Data(nil)
+	imported.SetId("tf-uploads")
+	if diags := resourceBucketCorsConfigurationRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to import CORS configuration: %v", diags)
+	}
+	rules := expandCORSRules(imported.Get(names.AttrCORSRule).(*schema.Set))
+	if len(rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(rules))
+	}
+	for _, rule := range rules {
+		if rule.MaxAgeSeconds != 3600 || len(rule.AllowedMethods) != 2 || len(rule.ExposeHeaders) != 1 {
+			t.Errorf("unexpected rule %+v", rule)
+		}
+	}
+
+	// Reordering the rules and their values is not a change.
+	raw[names.AttrCORSRule] = []interface{}{rule("http://localhost:3000", "https://app.example.com"), rule("https://example.com")}
+	raw[names.AttrCORSRule].([]interface{})[0].(map[string]interface{})[names.AttrAllowedMethods] = []interface{}{"GET", "PUT"}
+	diff, err := resourceTigrisBucketCorsConfiguration().Diff(ctx, imported.State(), terraform.NewResourceConfigRaw(raw), svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff for reordered rules, got %v", diff)
+	}
+
+	raw[names.AttrCORSRule].([]interface{})[1].(map[string]interface{})[names.AttrMaxAgeSeconds] = 60
+	diff, err = resourceTigrisBucketCorsConfiguration().Diff(ctx, imported.State(), terraform.NewResourceConfigRaw(raw), svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Error("expected a diff for a changed rule")
+	}
+
+	// Only the methods supported by CORS are allowed.
+	invalid := rule("https://example.com")
+	invalid[names.AttrAllowedMethods] = []interface{}{"PATCH"}
+	diags := resourceTigrisBucketCorsConfiguration().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		names.AttrBucket:   "tf-uploads",
+		names.AttrCORSRule: []interface{}{invalid},
+	}))
+	if !diags.HasError() {
+		t.Error("expected PATCH to be rejected")
+	}
+
+	if diags := resourceBucketCorsConfigurationDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to delete CORS configuration: %v", diags)
+	}
+	if diags := resourceBucketCorsConfigurationRead(ctx, imported, svc); diags.HasError() || imported.Id() != "" {
+		t.Errorf("expected deleted CORS configuration to be removed from state, got id=%q diags=%v", imported.Id(), diags)
+	}
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	out := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		out = append(out, v)
+	}
+
+	return out
+}
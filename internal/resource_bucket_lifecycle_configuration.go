@@ -0,0 +1,682 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+const (
+	lifecycleRuleStatusEnabled  = "Enabled"
+	lifecycleRuleStatusDisabled = "Disabled"
+
+	// lifecycleDateLayout is the layout lifecycle dates are read back in.
+	// Dates can also be configured as RFC 3339 timestamps at midnight UTC.
+	lifecycleDateLayout = "2006-01-02"
+)
+
+func resourceTigrisBucketLifecycleConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris bucket lifecycle configuration resource. This can be used to expire objects, " +
+			"transition them between storage classes and clean up noncurrent versions and incomplete multipart uploads.",
+		CreateWithoutTimeout: resourceBucketLifecycleConfigurationCreate,
+		ReadWithoutTimeout:   resourceBucketLifecycleConfigurationRead,
+		UpdateWithoutTimeout: resourceBucketLifecycleConfigurationUpdate,
+		DeleteWithoutTimeout: resourceBucketLifecycleConfigurationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceBucketLifecycleConfigurationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Tigris bucket.",
+			},
+			names.AttrRule: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    1000,
+				Description: "The lifecycle rules of the bucket.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						names.AttrID: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 255),
+							Description:  "The unique identifier of the rule.",
+						},
+						names.AttrStatus: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      lifecycleRuleStatusEnabled,
+							ValidateFunc: validation.StringInSlice([]string{lifecycleRuleStatusEnabled, lifecycleRuleStatusDisabled}, false),
+							Description:  "Whether the rule is applied, either `Enabled` or `Disabled`.",
+						},
+						names.AttrFilter: {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "The objects the rule applies to. The rule applies to all objects in the bucket when it is not set.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									names.AttrPrefix: {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The key prefix of the objects the rule applies to.",
+									},
+									names.AttrTags: {
+										Type:         schema.TypeMap,
+										Optional:     true,
+										Elem:         &schema.Schema{Type: schema.TypeString},
+										ValidateFunc: validBucketTags,
+										Description:  "The tags an object must all have for the rule to apply to it.",
+									},
+								},
+							},
+						},
+						names.AttrExpiration: {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "When the current versions of the objects expire. Exactly one of `days`, `date` or `expired_object_delete_marker` must be set.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									names.AttrDays: {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+										Description:  "The number of days after object creation when the objects expire.",
+									},
+									names.AttrDate: {
+										Type:             schema.TypeString,
+										Optional:         true,
+										ValidateFunc:     validLifecycleDate,
+										DiffSuppressFunc: suppressEquivalentLifecycleDate,
+										Description:      "The date the objects expire, for example `2030-01-01`.",
+									},
+									names.AttrExpiredObjectDeleteMarker: {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Description: "Whether to remove delete markers that have no noncurrent versions left.",
+									},
+								},
+							},
+						},
+						names.AttrTransition: {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Set:         lifecycleTransitionHash,
+							Description: "When the objects move to another storage class. The order of the transitions is not significant.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									names.AttrDays: {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+										Description:  "The number of days after object creation when the objects move. It cannot be set together with `date`.",
+									},
+									names.AttrDate: {
+										Type:             schema.TypeString,
+										Optional:         true,
+										ValidateFunc:     validLifecycleDate,
+										DiffSuppressFunc: suppressEquivalentLifecycleDate,
+										Description:      "The date the objects move, for example `2030-01-01`.",
+									},
+									names.AttrStorageClass: {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(transitionStorageClass_Values(), false),
+										Description:  "The storage class the objects move to, one of `STANDARD_IA`, `GLACIER` or `GLACIER_IR`.",
+									},
+								},
+							},
+						},
+						names.AttrNoncurrentVersionExpiration: {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "When the noncurrent versions of the objects expire.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									names.AttrNoncurrentDays: {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+										Description:  "The number of days after an object version becomes noncurrent when it expires.",
+									},
+									names.AttrNewerNoncurrentVersions: {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntBetween(1, 100),
+										Description:  "The number of newest noncurrent versions to keep regardless of their age.",
+									},
+								},
+							},
+						},
+						names.AttrAbortIncompleteMultipartUpload: {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "When incomplete multipart uploads are aborted.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									names.AttrDaysAfterInitiation: {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+										Description:  "The number of days after the upload started when it is aborted.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBucketLifecycleConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+
+	tflog.Info(ctx, "Creating bucket lifecycle configuration", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketLifecycle(ctx, bucketName, expandLifecycleRules(d.Get(names.AttrRule).([]interface{}))); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create bucket lifecycle configuration, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket lifecycle configuration created successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId(bucketName)
+
+	return resourceBucketLifecycleConfigurationRead(ctx, d, meta)
+}
+
+func resourceBucketLifecycleConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Checking bucket existence", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if !exists {
+		tflog.Warn(ctx, "Bucket not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+
+	d.Set(names.AttrBucket, bucketName)
+
+	rules, err := svc.GetBucketLifecycle(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket lifecycle configuration, %w", err))
+	}
+	if len(rules) == 0 {
+		tflog.Warn(ctx, "Bucket lifecycle configuration not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(names.AttrRule, flattenLifecycleRules(d, rules)); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to set %s, %w", names.AttrRule, err))
+	}
+
+	return nil
+}
+
+func resourceBucketLifecycleConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Updating bucket lifecycle configuration", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketLifecycle(ctx, bucketName, expandLifecycleRules(d.Get(names.AttrRule).([]interface{}))); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to update bucket lifecycle configuration, %w", err))
+	}
+
+	return resourceBucketLifecycleConfigurationRead(ctx, d, meta)
+}
+
+func resourceBucketLifecycleConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Deleting bucket lifecycle configuration", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.DeleteBucketLifecycle(ctx, bucketName); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to delete bucket lifecycle configuration, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket lifecycle configuration deleted successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId("")
+	return nil
+}
+
+func resourceBucketLifecycleConfigurationCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(names.AttrRule) {
+		return nil
+	}
+
+	return validateLifecycleRules(knownLifecycleRules(d, expandLifecycleRules(d.Get(names.AttrRule).([]interface{}))))
+}
+
+// knownLifecycleRules drops the rules with values that are unknown until
+// apply. Unknown values read as zero values, so validating those rules would
+// report errors that the configuration does not have.
+func knownLifecycleRules(d *schema.ResourceDiff, rules []types.LifecycleRule) []types.LifecycleRule {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return rules
+	}
+	rawRules := config.GetAttr(names.AttrRule)
+	if rawRules.IsNull() || !rawRules.IsKnown() || rawRules.LengthInt() != len(rules) {
+		return rules
+	}
+
+	known := make([]types.LifecycleRule, 0, len(rules))
+	for i, rule := range rules {
+		if rawRules.Index(cty.NumberIntVal(int64(i))).IsWhollyKnown() {
+			known = append(known, rule)
+		}
+	}
+
+	return known
+}
+
+// validateLifecycleRules catches the rule combinations that the lifecycle API
+// rejects, or that apply conflicting actions to the same objects, at plan time.
+func validateLifecycleRules(rules []types.LifecycleRule) error {
+	var errs []error
+
+	ids := map[string]bool{}
+	for _, rule := range rules {
+		if ids[rule.ID] {
+			errs = append(errs, fmt.Errorf("rule %q: duplicate rule id", rule.ID))
+		}
+		ids[rule.ID] = true
+
+		errs = append(errs, validateLifecycleRule(rule)...)
+	}
+
+	// Enabled rules with the same filter apply to the same objects, so they
+	// cannot both set the same action.
+	for i, rule := range rules {
+		for _, other := range rules[i+1:] {
+			if !rule.Enabled || !other.Enabled || !sameLifecycleFilter(rule, other) {
+				continue
+			}
+
+			for _, action := range conflictingLifecycleActions(rule, other) {
+				errs = append(errs, fmt.Errorf("rules %q and %q conflict: both set %s for the same objects", rule.ID, other.ID, action))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateLifecycleRule(rule types.LifecycleRule) []error {
+	var errs []error
+
+	if rule.Expiration == nil && len(rule.Transitions) == 0 && rule.NoncurrentVersionExpiration == nil && rule.AbortIncompleteMultipartUploadDays == 0 {
+		errs = append(errs, fmt.Errorf("rule %q: at least one of %s, %s, %s or %s must be set", rule.ID,
+			names.AttrExpiration, names.AttrTransition, names.AttrNoncurrentVersionExpiration, names.AttrAbortIncompleteMultipartUpload))
+	}
+
+	if expiration := rule.Expiration; expiration != nil {
+		set := 0
+		for _, ok := range []bool{expiration.Days > 0, expiration.Date != nil, expiration.ExpiredObjectDeleteMarker} {
+			if ok {
+				set++
+			}
+		}
+		if set != 1 {
+			errs = append(errs, fmt.Errorf("rule %q: exactly one of %s.%s, %s.%s or %s.%s must be set", rule.ID,
+				names.AttrExpiration, names.AttrDays, names.AttrExpiration, names.AttrDate, names.AttrExpiration, names.AttrExpiredObjectDeleteMarker))
+		}
+		if expiration.ExpiredObjectDeleteMarker && len(rule.Tags) > 0 {
+			errs = append(errs, fmt.Errorf("rule %q: %s cannot be used with a tag filter", rule.ID, names.AttrExpiredObjectDeleteMarker))
+		}
+	}
+
+	if rule.AbortIncompleteMultipartUploadDays > 0 && len(rule.Tags) > 0 {
+		errs = append(errs, fmt.Errorf("rule %q: %s cannot be used with a tag filter", rule.ID, names.AttrAbortIncompleteMultipartUpload))
+	}
+
+	storageClasses := map[types.StorageClass]bool{}
+	byDays, byDate := false, false
+	for _, transition := range rule.Transitions {
+		if transition.Date != nil && transition.Days > 0 {
+			errs = append(errs, fmt.Errorf("rule %q: transition to %s cannot set both %s and %s", rule.ID, transition.StorageClass, names.AttrDays, names.AttrDate))
+		}
+		if transition.Date != nil {
+			byDate = true
+		} else {
+			byDays = true
+		}
+
+		if storageClasses[transition.StorageClass] {
+			errs = append(errs, fmt.Errorf("rule %q: duplicate transition to %s", rule.ID, transition.StorageClass))
+		}
+		storageClasses[transition.StorageClass] = true
+
+		if expiration := rule.Expiration; expiration != nil {
+			if transition.Date == nil && expiration.Days > 0 && transition.Days >= expiration.Days {
+				errs = append(errs, fmt.Errorf("rule %q: transition to %s after %d days must happen before the expiration after %d days",
+					rule.ID, transition.StorageClass, transition.Days, expiration.Days))
+			}
+			if transition.Date != nil && expiration.Date != nil && !transition.Date.Before(*expiration.Date) {
+				errs = append(errs, fmt.Errorf("rule %q: transition to %s on %s must happen before the expiration on %s",
+					rule.ID, transition.StorageClass, transition.Date.Format(lifecycleDateLayout), expiration.Date.Format(lifecycleDateLayout)))
+			}
+		}
+	}
+	if byDays && byDate {
+		errs = append(errs, fmt.Errorf("rule %q: transitions must all use either %s or %s", rule.ID, names.AttrDays, names.AttrDate))
+	}
+
+	return errs
+}
+
+func sameLifecycleFilter(a, b types.LifecycleRule) bool {
+	if a.Prefix != b.Prefix || len(a.Tags) != len(b.Tags) {
+		return false
+	}
+	for key, value := range a.Tags {
+		if other, ok := b.Tags[key]; !ok || other != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func conflictingLifecycleActions(a, b types.LifecycleRule) []string {
+	var actions []string
+
+	if a.Expiration != nil && b.Expiration != nil {
+		actions = append(actions, names.AttrExpiration)
+	}
+	for _, transition := range a.Transitions {
+		for _, other := range b.Transitions {
+			if transition.StorageClass == other.StorageClass {
+				actions = append(actions, fmt.Sprintf("%s to %s", names.AttrTransition, transition.StorageClass))
+			}
+		}
+	}
+	if a.NoncurrentVersionExpiration != nil && b.NoncurrentVersionExpiration != nil {
+		actions = append(actions, names.AttrNoncurrentVersionExpiration)
+	}
+	if a.AbortIncompleteMultipartUploadDays > 0 && b.AbortIncompleteMultipartUploadDays > 0 {
+		actions = append(actions, names.AttrAbortIncompleteMultipartUpload)
+	}
+
+	return actions
+}
+
+// validLifecycleDate validates that the date is at midnight UTC, which the
+// lifecycle API requires.
+func validLifecycleDate(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parseLifecycleDate(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q %s", k, err))
+	}
+
+	return
+}
+
+func parseLifecycleDate(value string) (time.Time, error) {
+	date, err := time.Parse(lifecycleDateLayout, value)
+	if err != nil {
+		date, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("must be a date like 2030-01-01 or an RFC 3339 timestamp, got %q", value)
+		}
+	}
+
+	date = date.UTC()
+	if !date.Equal(date.Truncate(24 * time.Hour)) {
+		return time.Time{}, fmt.Errorf("must be at midnight UTC, got %q", value)
+	}
+
+	return date, nil
+}
+
+// suppressEquivalentLifecycleDate suppresses the diff between a date and the
+// same date written as a timestamp.
+func suppressEquivalentLifecycleDate(_, old, new string, _ *schema.ResourceData) bool {
+	oldDate, err := parseLifecycleDate(old)
+	if err != nil {
+		return false
+	}
+	newDate, err := parseLifecycleDate(new)
+	if err != nil {
+		return false
+	}
+
+	return oldDate.Equal(newDate)
+}
+
+// lifecycleTransitionHash hashes transitions by their normalized date, so the
+// same date written differently is the same transition.
+func lifecycleTransitionHash(v interface{}) int {
+	m := v.(map[string]interface{})
+
+	date, _ := m[names.AttrDate].(string)
+	if parsed, err := parseLifecycleDate(date); err == nil {
+		date = parsed.Format(lifecycleDateLayout)
+	}
+	days, _ := m[names.AttrDays].(int)
+	storageClass, _ := m[names.AttrStorageClass].(string)
+
+	return schema.HashString(fmt.Sprintf("%d-%s-%s", days, date, storageClass))
+}
+
+func expandLifecycleRules(values []interface{}) []types.LifecycleRule {
+	rules := make([]types.LifecycleRule, 0, len(values))
+	for _, v := range values {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		rule := types.LifecycleRule{
+			ID:      m[names.AttrID].(string),
+			Enabled: m[names.AttrStatus].(string) != lifecycleRuleStatusDisabled,
+		}
+
+		if filter := firstBlock(m[names.AttrFilter]); filter != nil {
+			rule.Prefix = filter[names.AttrPrefix].(string)
+			if tags := expandStringMap(filter[names.AttrTags].(map[string]interface{})); len(tags) > 0 {
+				rule.Tags = tags
+			}
+		}
+
+		if expiration := firstBlock(m[names.AttrExpiration]); expiration != nil {
+			rule.Expiration = &types.LifecycleExpiration{
+				Days:                      int32(expiration[names.AttrDays].(int)),
+				Date:                      expandLifecycleDate(expiration[names.AttrDate].(string)),
+				ExpiredObjectDeleteMarker: expiration[names.AttrExpiredObjectDeleteMarker].(bool),
+			}
+		}
+
+		if transitions, ok := m[names.AttrTransition].(*schema.Set); ok {
+			for _, t := range transitions.List() {
+				transition := t.(map[string]interface{})
+				rule.Transitions = append(rule.Transitions, types.LifecycleTransition{
+					Days:         int32(transition[names.AttrDays].(int)),
+					Date:         expandLifecycleDate(transition[names.AttrDate].(string)),
+					StorageClass: types.StorageClass(transition[names.AttrStorageClass].(string)),
+				})
+			}
+			sort.Slice(rule.Transitions, func(i, j int) bool {
+				return rule.Transitions[i].StorageClass < rule.Transitions[j].StorageClass
+			})
+		}
+
+		if expiration := firstBlock(m[names.AttrNoncurrentVersionExpiration]); expiration != nil {
+			rule.NoncurrentVersionExpiration = &types.NoncurrentVersionExpiration{
+				NoncurrentDays:          int32(expiration[names.AttrNoncurrentDays].(int)),
+				NewerNoncurrentVersions: int32(expiration[names.AttrNewerNoncurrentVersions].(int)),
+			}
+		}
+
+		if abort := firstBlock(m[names.AttrAbortIncompleteMultipartUpload]); abort != nil {
+			rule.AbortIncompleteMultipartUploadDays = int32(abort[names.AttrDaysAfterInitiation].(int))
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// flattenLifecycleRules converts the rules for the schema. A filter that
+// matches every object is kept as an empty block when it is configured that
+// way, so that `filter {}` and no filter both read back without a diff.
+func flattenLifecycleRules(d *schema.ResourceData, rules []types.LifecycleRule) []interface{} {
+	values := make([]interface{}, 0, len(rules))
+	for i, rule := range rules {
+		status := lifecycleRuleStatusEnabled
+		if !rule.Enabled {
+			status = lifecycleRuleStatusDisabled
+		}
+
+		m := map[string]interface{}{
+			names.AttrID:     rule.ID,
+			names.AttrStatus: status,
+		}
+
+		emptyFilter := rule.Prefix == "" && len(rule.Tags) == 0
+		if !emptyFilter || d.Get(fmt.Sprintf("%s.%d.%s.#", names.AttrRule, i, names.AttrFilter)).(int) > 0 {
+			m[names.AttrFilter] = []interface{}{map[string]interface{}{
+				names.AttrPrefix: rule.Prefix,
+				names.AttrTags:   rule.Tags,
+			}}
+		}
+
+		if expiration := rule.Expiration; expiration != nil {
+			m[names.AttrExpiration] = []interface{}{map[string]interface{}{
+				names.AttrDays:                      int(expiration.Days),
+				names.AttrDate:                      flattenLifecycleDate(expiration.Date),
+				names.AttrExpiredObjectDeleteMarker: expiration.ExpiredObjectDeleteMarker,
+			}}
+		}
+
+		transitions := make([]interface{}, 0, len(rule.Transitions))
+		for _, transition := range rule.Transitions {
+			transitions = append(transitions, map[string]interface{}{
+				names.AttrDays:         int(transition.Days),
+				names.AttrDate:         flattenLifecycleDate(transition.Date),
+				names.AttrStorageClass: string(transition.StorageClass),
+			})
+		}
+		m[names.AttrTransition] = transitions
+
+		if expiration := rule.NoncurrentVersionExpiration; expiration != nil {
+			m[names.AttrNoncurrentVersionExpiration] = []interface{}{map[string]interface{}{
+				names.AttrNoncurrentDays:          int(expiration.NoncurrentDays),
+				names.AttrNewerNoncurrentVersions: int(expiration.NewerNoncurrentVersions),
+			}}
+		}
+
+		if rule.AbortIncompleteMultipartUploadDays > 0 {
+			m[names.AttrAbortIncompleteMultipartUpload] = []interface{}{map[string]interface{}{
+				names.AttrDaysAfterInitiation: int(rule.AbortIncompleteMultipartUploadDays),
+			}}
+		}
+
+		values = append(values, m)
+	}
+
+	return values
+}
+
+func expandLifecycleDate(value string) *time.Time {
+	date, err := parseLifecycleDate(value)
+	if err != nil {
+		return nil
+	}
+
+	return &date
+}
+
+func flattenLifecycleDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+
+	return date.UTC().Format(lifecycleDateLayout)
+}
+
+// firstBlock returns the attributes of a block with at most one item, or nil
+// when the block is not set.
+func firstBlock(v interface{}) map[string]interface{} {
+	values, ok := v.([]interface{})
+	if !ok || len(values) == 0 {
+		return nil
+	}
+
+	m, _ := values[0].(map[string]interface{})
+	return m
+}
+
+func transitionStorageClass_Values() []string {
+	values := []string{}
+	for _, value := range storageClass_Values() {
+		if value != string(types.StorageClassStandard) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,301 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func TestResourceBucketLifecycleConfiguration(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	if err := svc.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-scratch"}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := map[string]interface{}{
+		names.AttrBucket: "tf-scratch",
+		names.AttrRule: []interface{}{
+			map[string]interface{}{
+				names.AttrID:     "tmp",
+				names.AttrFilter: []interface{}{map[string]interface{}{names.AttrPrefix: "tmp/", names.AttrTags: map[string]interface{}{"ttl": "short", "team": "data"}}},
+				names.AttrExpiration: []interface{}{
+					map[string]interface{}{names.AttrDays: 30},
+				},
+				names.AttrTransition: []interface{}{
+					map[string]interface{}{names.AttrDays: 7, names.AttrStorageClass: "STANDARD_IA"},
+					map[string]interface{}{names.AttrDays: 14, names.AttrStorageClass: "GLACIER"},
+				},
+			},
+			map[string]interface{}{
+				names.AttrID:     "archive",
+				names.AttrStatus: "Disabled",
+				names.AttrFilter: []interface{}{map[string]interface{}{names.AttrTags: map[string]interface{}{"archive": "true"}}},
+				names.AttrExpiration: []interface{}{
+					map[string]interface{}{names.AttrDate: "2030-01-01T00:00:00Z"},
+				},
+			},
+			map[string]interface{}{
+				names.AttrID:     "cleanup",
+				names.AttrFilter: []interface{}{map[string]interface{}{}},
+				names.AttrNoncurrentVersionExpiration: []interface{}{
+					map[string]interface{}{names.AttrNoncurrentDays: 7, names.AttrNewerNoncurrentVersions: 3},
+				},
+				names.AttrAbortIncompleteMultipartUpload: []interface{}{
+					map[string]interface{}{names.AttrDaysAfterInitiation: 2},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucketLifecycleConfiguration().Schema, raw)
+	if diags := resourceBucketLifecycleConfigurationCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create lifecycle configuration: %v", diags)
+	}
+
+	imported := resourceTigrisBucketLifecycleConfiguration().Data(nil)
+	imported.SetId("tf-scratch")
+	if diags := resourceBucketLifecycleConfigurationRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to import lifecycle configuration: %v", diags)
+	}
+	rules := expandLifecycleRules(imported.Get(names.AttrRule).([]interface{}))
+	if len(rules) != 3 {
+		t.Fatalf("got %d rules, want 3", len(rules))
+	}
+	if rule := rules[0]; rule.Prefix != "tmp/" || rule.Tags["ttl"] != "short" || rule.Tags["team"] != "data" || len(rule.Transitions) != 2 || rule.Expiration.Days != 30 {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+	if rule := rules[1]; rule.Enabled || rule.Tags["archive"] != "true" || flattenLifecycleDate(rule.Expiration.Date) != "2030-01-01" {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+	if rule := rules[2]; rule.NoncurrentVersionExpiration.NewerNoncurrentVersions != 3 || rule.AbortIncompleteMultipartUploadDays != 2 {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+	if got := imported.Get("rule.2.filter.#").(int); got != 0 {
+		t.Errorf("expected imported rule without filter, got %d filters", got)
+	}
+
+	// The configuration reads back without a diff, whatever the order of the
+	// transitions and the format of the dates.
+	transitions := raw[names.AttrRule].([]interface{})[0].(map[string]interface{})[names.AttrTransition].([]interface{})
+	transitions[0], transitions[1] = transitions[1], transitions[0]
+	diff, err := resourceTigrisBucketLifecycleConfiguration().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff)
+	}
+
+	transitions[0].(map[string]interface{})[names.AttrDays] = 21
+	diff, err = resourceTigrisBucketLifecycleConfiguration().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Error("expected a diff for a changed transition")
+	}
+
+	if diags := resourceBucketLifecycleConfigurationDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to delete lifecycle configuration: %v", diags)
+	}
+	if diags := resourceBucketLifecycleConfigurationRead(ctx, imported, svc); diags.HasError() || imported.Id() != "" {
+		t.Errorf("expected deleted lifecycle configuration to be removed from state, got id=%q diags=%v", imported.Id(), diags)
+	}
+}
+
+func TestResourceBucketLifecycleConfigurationValidation(t *testing.T) {
+	ctx := context.Background()
+
+	rule := func(id string, attrs map[string]interface{}) map[string]interface{} {
+		attrs[names.AttrID] = id
+		return attrs
+	}
+	expireDays := func(days int) []interface{} {
+		return []interface{}{map[string]interface{}{names.AttrDays: days}}
+	}
+
+	tests := []struct {
+		name  string
+		rules []interface{}
+		err   string
+	}{
+		{
+			name:  "no action",
+			rules: []interface{}{rule("a", map[string]interface{}{})},
+			err:   `rule "a": at least one of`,
+		},
+		{
+			name: "duplicate id",
+			rules: []interface{}{
+				rule("a", map[string]interface{}{names.AttrExpiration: expireDays(1)}),
+				rule("a", map[string]interface{}{names.AttrFilter: []interface{}{map[string]interface{}{names.AttrPrefix: "logs/"}}, names.AttrExpiration: expireDays(1)}),
+			},
+			err: `rule "a": duplicate rule id`,
+		},
+		{
+			name: "expiration days and date",
+			rules: []interface{}{rule("a", map[string]interface{}{names.AttrExpiration: []interface{}{
+				map[string]interface{}{names.AttrDays: 1, names.AttrDate: "2030-01-01"},
+			}})},
+			err: "exactly one of expiration.days, expiration.date or expiration.expired_object_delete_marker",
+		},
+		{
+			name: "delete marker with tags",
+			rules: []interface{}{rule("a", map[string]interface{}{
+				names.AttrFilter:     []interface{}{map[string]interface{}{names.AttrTags: map[string]interface{}{"a": "b"}}},
+				names.AttrExpiration: []interface{}{map[string]interface{}{names.AttrExpiredObjectDeleteMarker: true}},
+			})},
+			err: "expired_object_delete_marker cannot be used with a tag filter",
+		},
+		{
+			name: "transition after expiration",
+			rules: []interface{}{rule("a", map[string]interface{}{
+				names.AttrExpiration: expireDays(30),
+				names.AttrTransition: []interface{}{map[string]interface{}{names.AttrDays: 30, names.AttrStorageClass: "GLACIER"}},
+			})},
+			err: "must happen before the expiration after 30 days",
+		},
+		{
+			name: "transitions mixing days and dates",
+			rules: []interface{}{rule("a", map[string]interface{}{names.AttrTransition: []interface{}{
+				map[string]interface{}{names.AttrDays: 30, names.AttrStorageClass: "GLACIER"},
+				map[string]interface{}{names.AttrDate: "2030-01-01", names.AttrStorageClass: "STANDARD_IA"},
+			}})},
+			err: "transitions must all use either days or date",
+		},
+		{
+			name: "conflicting rules",
+			rules: []interface{}{
+				rule("a", map[string]interface{}{names.AttrExpiration: expireDays(1)}),
+				rule("b", map[string]interface{}{names.AttrExpiration: expireDays(7)}),
+			},
+			err: `rules "a" and "b" conflict: both set expiration for the same objects`,
+		},
+		{
+			name: "disabled rule does not conflict",
+			rules: []interface{}{
+				rule("a", map[string]interface{}{names.AttrExpiration: expireDays(1)}),
+				rule("b", map[string]interface{}{names.AttrStatus: "Disabled", names.AttrExpiration: expireDays(7)}),
+			},
+		},
+		{
+			name: "different filters do not conflict",
+			rules: []interface{}{
+				rule("a", map[string]interface{}{names.AttrExpiration: expireDays(1)}),
+				rule("b", map[string]interface{}{names.AttrFilter: []interface{}{map[string]interface{}{names.AttrPrefix: "logs/"}}, names.AttrExpiration: expireDays(7)}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resourceTigrisBucketLifecycleConfiguration().Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				names.AttrBucket: "tf-scratch",
+				names.AttrRule:   tt.rules,
+			}), nil)
+			if tt.err == "" && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestResourceBucketLifecycleConfigurationUnknownValues(t *testing.T) {
+	ctx := context.Background()
+	r := resourceTigrisBucketLifecycleConfiguration()
+
+	rule := func(id, prefix string, days int) map[string]interface{} {
+		return map[string]interface{}{
+			names.AttrID:         id,
+			names.AttrFilter:     []interface{}{map[string]interface{}{names.AttrPrefix: prefix}},
+			names.AttrExpiration: []interface{}{map[string]interface{}{names.AttrDays: days}},
+		}
+	}
+	rulePath := func(i int) cty.Path {
+		return cty.GetAttrPath(names.AttrRule).IndexInt(i)
+	}
+
+	tests := []struct {
+		name    string
+		rules   []interface{}
+		unknown cty.Path
+		err     string
+	}{
+		{
+			// An unknown prefix reads as no prefix, the filter of rule a.
+			name:    "unknown prefix",
+			rules:   []interface{}{rule("a", "", 1), rule("b", "logs/", 7)},
+			unknown: rulePath(1).GetAttr(names.AttrFilter).IndexInt(0).GetAttr(names.AttrPrefix),
+		},
+		{
+			// Unknown days read as an expiration without an action.
+			name:    "unknown days",
+			rules:   []interface{}{rule("a", "", 1), rule("b", "logs/", 7)},
+			unknown: rulePath(1).GetAttr(names.AttrExpiration).IndexInt(0).GetAttr(names.AttrDays),
+		},
+		{
+			name:    "known rules are still checked",
+			rules:   []interface{}{rule("a", "", 1), rule("b", "", 7), rule("c", "logs/", 7)},
+			unknown: rulePath(2).GetAttr(names.AttrFilter).IndexInt(0).GetAttr(names.AttrPrefix),
+			err:     `rules "a" and "b" conflict`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Terraform sends the configuration with its unknown values as
+			// the raw configuration.
+			body, err := json.Marshal(map[string]interface{}{names.AttrBucket: "tf-scratch", names.AttrRule: tt.rules})
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, err := ctyjson.Unmarshal(body, r.CoreConfigSchema().ImpliedType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, err = cty.Transform(config, func(path cty.Path, v cty.Value) (cty.Value, error) {
+				if path.Equals(tt.unknown) {
+					return cty.UnknownVal(v.Type()), nil
+				}
+				return v, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = r.Diff(ctx, &terraform.InstanceState{RawConfig: config}, terraform.NewResourceConfigShimmed(config, r.CoreConfigSchema()), nil)
+			if tt.err == "" && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestValidLifecycleDate(t *testing.T) {
+	for _, value := range []string{"2030-01-01", "2030-01-01T00:00:00Z", "2030-01-01T01:00:00+01:00"} {
+		if _, errs := validLifecycleDate(value, names.AttrDate); len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", value, errs)
+		}
+	}
+	for _, value := range []string{"2030-01-01T12:00:00Z", "01/01/2030", ""} {
+		if _, errs := validLifecycleDate(value, names.AttrDate); len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", value)
+		}
+	}
+}
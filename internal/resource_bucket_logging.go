@@ -0,0 +1,211 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func resourceTigrisBucketLogging() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris bucket logging resource. This can be used to deliver the server access logs " +
+			"of a bucket into a target bucket.",
+		CreateWithoutTimeout: resourceBucketLoggingCreate,
+		ReadWithoutTimeout:   resourceBucketLoggingRead,
+		UpdateWithoutTimeout: resourceBucketLoggingUpdate,
+		DeleteWithoutTimeout: resourceBucketLoggingDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceBucketLoggingCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Tigris bucket to deliver the access logs of.",
+			},
+			names.AttrTargetBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the existing Tigris bucket to deliver the access logs into. It must be a different bucket.",
+			},
+			names.AttrTargetPrefix: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The prefix of the access log objects in the target bucket.",
+			},
+		},
+	}
+}
+
+func resourceBucketLoggingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+	logging := expandBucketLogging(d)
+
+	if err := validateLoggingTarget(ctx, svc, bucketName, logging.TargetBucket); err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, "Creating bucket logging", map[string]interface{}{
+		"bucket_name":   bucketName,
+		"target_bucket": logging.TargetBucket,
+	})
+
+	if err := svc.PutBucketLogging(ctx, bucketName, logging); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create bucket logging, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket logging created successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId(bucketName)
+
+	return resourceBucketLoggingRead(ctx, d, meta)
+}
+
+func resourceBucketLoggingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Checking bucket existence", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if !exists {
+		tflog.Warn(ctx, "Bucket not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+
+	d.Set(names.AttrBucket, bucketName)
+
+	logging, err := svc.GetBucketLogging(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket logging, %w", err))
+	}
+	if logging == nil {
+		tflog.Warn(ctx, "Bucket logging not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(names.AttrTargetBucket, logging.TargetBucket)
+	d.Set(names.AttrTargetPrefix, logging.TargetPrefix)
+
+	return nil
+}
+
+func resourceBucketLoggingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+	logging := expandBucketLogging(d)
+
+	if d.HasChange(names.AttrTargetBucket) {
+		if err := validateLoggingTarget(ctx, svc, bucketName, logging.TargetBucket); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	tflog.Info(ctx, "Updating bucket logging", map[string]interface{}{
+		"bucket_name":   bucketName,
+		"target_bucket": logging.TargetBucket,
+	})
+
+	if err := svc.PutBucketLogging(ctx, bucketName, logging); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to update bucket logging, %w", err))
+	}
+
+	return resourceBucketLoggingRead(ctx, d, meta)
+}
+
+func resourceBucketLoggingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Disabling bucket logging", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketLogging(ctx, bucketName, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to delete bucket logging, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket logging disabled successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId("")
+	return nil
+}
+
+// resourceBucketLoggingCustomizeDiff rejects logging into the bucket itself.
+// The target bucket may be created in the same apply, so its existence is only
+// checked when the configuration is applied.
+func resourceBucketLoggingCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(names.AttrBucket) || !d.NewValueKnown(names.AttrTargetBucket) {
+		return nil
+	}
+
+	bucketName := d.Get(names.AttrBucket).(string)
+	if d.Get(names.AttrTargetBucket).(string) == bucketName {
+		return fmt.Errorf("%s must be a different bucket than %q", names.AttrTargetBucket, bucketName)
+	}
+
+	return nil
+}
+
+func validateLoggingTarget(ctx context.Context, svc *Client, bucketName, targetBucket string) error {
+	if targetBucket == bucketName {
+		return fmt.Errorf("%s must be a different bucket than %q", names.AttrTargetBucket, bucketName)
+	}
+
+	exists, err := svc.HeadBucket(ctx, targetBucket)
+	if err != nil {
+		return fmt.Errorf("unable to read target bucket %q, %w", targetBucket, err)
+	}
+	if !exists {
+		return fmt.Errorf("target bucket %q does not exist", targetBucket)
+	}
+
+	return nil
+}
+
+func expandBucketLogging(d *schema.ResourceData) *types.BucketLogging {
+	return &types.BucketLogging{
+		TargetBucket: d.Get(names.AttrTargetBucket).(string),
+		TargetPrefix: d.Get(names.AttrTargetPrefix).(string),
+	}
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+// unknownValue is the value terraform.NewResourceConfigRaw reads as unknown
+// until apply.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestResourceBucketLogging(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	for _, bucketName := range []string{"tf-public", "tf-access-logs"} {
+		if err := svc.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: bucketName}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := resourceTigrisBucketLogging()
+	plan := func(state *terraform.InstanceState, targetBucket string) (*terraform.InstanceDiff, error) {
+		return r.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+			names.AttrBucket:       "tf-public",
+			names.AttrTargetBucket: targetBucket,
+		}), svc)
+	}
+
+	// Logging to the same bucket is rejected at plan time when the target
+	// is known.
+	if _, err := plan(nil, "tf-public"); err == nil || !strings.Contains(err.Error(), "must be a different bucket") {
+		t.Fatalf("expected same bucket error, got %v", err)
+	}
+
+	// A target bucket created in the same apply is only checked at apply
+	// time.
+	if _, err := plan(nil, unknownValue); err != nil {
+		t.Fatalf("unexpected error planning an unknown target: %v", err)
+	}
+	for target, want := range map[string]string{
+		"tf-public":       "must be a different bucket",
+		"tf-missing-logs": `target bucket "tf-missing-logs" does not exist`,
+	} {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			names.AttrBucket:       "tf-public",
+			names.AttrTargetBucket: target,
+		})
+		if diags := resourceBucketLoggingCreate(ctx, d, svc); !diags.HasError() || !strings.Contains(diags[0].Summary, want) {
+			t.Errorf("expected error containing %q creating logging to %s, got %v", want, target, diags)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		names.AttrBucket:       "tf-public",
+		names.AttrTargetBucket: "tf-access-logs",
+		names.AttrTargetPrefix: "tf-public/",
+	})
+	if diags := resourceBucketLoggingCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create logging: %v", diags)
+	}
+
+	imported := r.Data(nil)
+	imported.SetId("tf-public")
+	if diags := resourceBucketLoggingRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to import logging: %v", diags)
+	}
+	if got := imported.Get(names.AttrTargetBucket).(string); got != "tf-access-logs" {
+		t.Errorf("target_bucket = %q, want %q", got, "tf-access-logs")
+	}
+	if got := imported.Get(names.AttrTargetPrefix).(string); got != "tf-public/" {
+		t.Errorf("target_prefix = %q, want %q", got, "tf-public/")
+	}
+
+	// Changing the target checks the new target.
+	diff, err := plan(d.State(), "tf-missing-logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, diags := r.Apply(ctx, d.State(), diff, svc); !diags.HasError() || !strings.Contains(diags[0].Summary, `target bucket "tf-missing-logs" does not exist`) {
+		t.Errorf("expected missing target error on update, got %v", diags)
+	}
+
+	// Logging disabled outside of Terraform removes the resource from state.
+	if diags := resourceBucketLoggingDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to delete logging: %v", diags)
+	}
+	if diags := resourceBucketLoggingRead(ctx, imported, svc); diags.HasError() || imported.Id() != "" {
+		t.Errorf("expected disabled logging to be removed from state, got id=%q diags=%v", imported.Id(), diags)
+	}
+}
@@ -0,0 +1,299 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func resourceTigrisBucketNotification() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris bucket notification resource. This can be used to POST object events of a " +
+			"bucket to a webhook.",
+		CreateWithoutTimeout: resourceBucketNotificationCreate,
+		ReadWithoutTimeout:   resourceBucketNotificationRead,
+		UpdateWithoutTimeout: resourceBucketNotificationUpdate,
+		DeleteWithoutTimeout: resourceBucketNotificationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Tigris bucket.",
+			},
+			names.AttrWebhookURL: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The URL object events are POSTed to.",
+			},
+			names.AttrEvents: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(notificationEvent_Values(), false),
+				},
+				Description: "The object events to notify about, `OBJECT_CREATED_PUT` or `OBJECT_DELETED`. All events are sent when it is not set.",
+			},
+			names.AttrPrefix: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key prefix of the objects to notify about.",
+			},
+			names.AttrAuthToken: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{names.AttrBasicAuthUsername, names.AttrBasicAuthPassword},
+				Description: "The bearer token sent to the webhook. It is write-only: it is not stored in the plan or state, " +
+					"and is never read back from Tigris. Change `auth_version` to send a new token. Requires Terraform 1.11 or later.",
+			},
+			names.AttrBasicAuthUsername: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{names.AttrBasicAuthPassword},
+				Description:  "The basic auth username sent to the webhook.",
+			},
+			names.AttrBasicAuthPassword: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				RequiredWith: []string{names.AttrBasicAuthUsername},
+				Description: "The basic auth password sent to the webhook. It is write-only: it is not stored in the plan or state, " +
+					"and is never read back from Tigris. Change `auth_version` to send a new password. Requires Terraform 1.11 or later.",
+			},
+			names.AttrAuthVersion: {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "A version of `auth_token` or `basic_auth_password`. Since those are write-only, changing them " +
+					"alone does not update the notification; change this value to send them again.",
+			},
+		},
+	}
+}
+
+func resourceBucketNotificationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+
+	input := &types.BucketUpdateInput{
+		Bucket:              bucketName,
+		ObjectNotifications: expandBucketNotification(d),
+	}
+
+	tflog.Info(ctx, "Creating bucket notification", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.UpdateBucket(ctx, input); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create bucket notification, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket notification created successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId(bucketName)
+
+	return resourceBucketNotificationRead(ctx, d, meta)
+}
+
+func resourceBucketNotificationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Checking bucket existence", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if !exists {
+		tflog.Warn(ctx, "Bucket not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+
+	d.Set(names.AttrBucket, bucketName)
+
+	tflog.Info(ctx, "Fetching bucket metadata", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	metadata, err := svc.GetBucketMetadata(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket metadata, %w", err))
+	}
+
+	notifications := metadata.ObjectNotifications
+	if notifications == nil || notifications.WebhookURL == "" {
+		tflog.Warn(ctx, "Bucket notification not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+
+	events := make([]string, 0, len(notifications.Events))
+	for _, event := range notifications.Events {
+		events = append(events, string(event))
+	}
+
+	d.Set(names.AttrWebhookURL, notifications.WebhookURL)
+	d.Set(names.AttrEvents, flattenStringSet(events))
+	d.Set(names.AttrPrefix, notifications.Prefix)
+
+	// The token and the password are write-only, so they are neither read
+	// back nor kept in the state.
+	basicAuthUsername := ""
+	if notifications.Auth != nil {
+		basicAuthUsername = notifications.Auth.BasicUsername
+	}
+	d.Set(names.AttrBasicAuthUsername, basicAuthUsername)
+
+	return nil
+}
+
+func resourceBucketNotificationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	input := &types.BucketUpdateInput{
+		Bucket: bucketName,
+	}
+	needsUpdate := false
+
+	tflog.Info(ctx, "Updating bucket notification", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	//
+	// Bucket Notification Config.
+	//
+	if d.HasChangesExcept(names.AttrBucket) {
+		input.ObjectNotifications = expandBucketNotification(d)
+
+		tflog.Info(ctx, "Will update bucket notification", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		err := svc.UpdateBucket(ctx, input)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to update bucket notification, %w", err))
+		}
+	}
+
+	tflog.Info(ctx, "Bucket notification updated successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	return resourceBucketNotificationRead(ctx, d, meta)
+}
+
+func resourceBucketNotificationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	input := &types.BucketUpdateInput{
+		Bucket:              bucketName,
+		ObjectNotifications: &types.BucketNotificationConfig{},
+	}
+
+	tflog.Info(ctx, "Deleting bucket notification", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	err := svc.UpdateBucket(ctx, input)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to delete bucket notification, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket notification deleted successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId("")
+	return nil
+}
+
+func expandBucketNotification(d *schema.ResourceData) *types.BucketNotificationConfig {
+	config := &types.BucketNotificationConfig{
+		WebhookURL: d.Get(names.AttrWebhookURL).(string),
+		Prefix:     d.Get(names.AttrPrefix).(string),
+	}
+
+	for _, event := range expandStringSet(d.Get(names.AttrEvents).(*schema.Set)) {
+		config.Events = append(config.Events, types.NotificationEvent(event))
+	}
+
+	if token := getWriteOnlyString(d, names.AttrAuthToken); token != "" {
+		config.Auth = &types.BucketNotificationAuth{Token: token}
+	}
+	if username := d.Get(names.AttrBasicAuthUsername).(string); username != "" {
+		config.Auth = &types.BucketNotificationAuth{
+			BasicUsername: username,
+			BasicPassword: getWriteOnlyString(d, names.AttrBasicAuthPassword),
+		}
+	}
+
+	return config
+}
+
+// getWriteOnlyString returns the configured value of a write-only attribute,
+// which is only available from the raw configuration.
+func getWriteOnlyString(d *schema.ResourceData, key string) string {
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() || !value.Type().Equals(cty.String) || value.IsNull() || !value.IsKnown() {
+		return ""
+	}
+
+	return value.AsString()
+}
+
+func notificationEvent_Values() []string {
+	var event types.NotificationEvent
+
+	values := []string{}
+	for _, value := range event.Values() {
+		values = append(values, string(value))
+	}
+
+	return values
+}
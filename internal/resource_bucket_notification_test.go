@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/memory"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func TestResourceBucketNotification(t *testing.T) {
+	ctx := context.Background()
+
+	// Keep the notification configurations sent to the metadata API.
+	backend := memory.NewBackend()
+	var sent []*types.BucketNotificationConfig
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodPatch {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			var update types.BucketUpdateRequest
+			if err := json.Unmarshal(body, &update); err != nil {
+				return nil, err
+			}
+			sent = append(sent, update.ObjectNotifications)
+		}
+
+		return backend.RoundTrip(req)
+	})
+	svc, err := NewClient(memory.Endpoint, "memory", "memory", WithTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-events"}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceTigrisBucketNotification()
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		names.AttrBucket:            "tf-events",
+		names.AttrWebhookURL:        "https://example.com/hook",
+		names.AttrAuthToken:         "token",
+		names.AttrBasicAuthUsername: "user",
+		names.AttrBasicAuthPassword: "pass",
+	}))
+	if !diags.HasError() {
+		t.Error("expected token and basic auth to conflict")
+	}
+
+	// Like Terraform, keep write-only values out of the plan so they only
+	// reach the provider through the raw configuration.
+	apply := func(state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+		t.Helper()
+
+		body, err := json.Marshal(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rawConfig, err := ctyjson.Unmarshal(body, r.CoreConfigSchema().ImpliedType())
+		if err != nil {
+			t.Fatal(err)
+		}
+		config := terraform.NewResourceConfigRaw(raw)
+		config.CtyValue = rawConfig
+
+		diff, err := r.Diff(ctx, state, config, svc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff == nil {
+			return state, nil
+		}
+		for k, s := range r.Schema {
+			if s.WriteOnly {
+				delete(diff.Attributes, k)
+			}
+		}
+		diff.RawConfig = rawConfig
+
+		sent = nil
+		return r.Apply(ctx, state, diff, svc)
+	}
+
+	raw := map[string]interface{}{
+		names.AttrBucket:     "tf-events",
+		names.AttrWebhookURL: "https://example.com/hook",
+		names.AttrEvents:     []interface{}{"OBJECT_DELETED"},
+		names.AttrPrefix:     "images/",
+		names.AttrAuthToken:  "s3cr3t",
+	}
+	state, diags := apply(nil, raw)
+	if diags.HasError() {
+		t.Fatalf("unable to create notification: %v", diags)
+	}
+	if len(sent) != 1 || sent[0] == nil || sent[0].Auth == nil || sent[0].Auth.Token != "s3cr3t" {
+		t.Errorf("expected create to send the token, got %+v", sent)
+	}
+	if got := state.Attributes[names.AttrAuthToken]; got != "" {
+		t.Errorf("expected auth_token not to be stored in state, got %q", got)
+	}
+
+	// Updates that do not change the auth send the token again, since the
+	// whole configuration is replaced.
+	raw[names.AttrPrefix] = "videos/"
+	state, diags = apply(state, raw)
+	if diags.HasError() {
+		t.Fatalf("unable to update notification: %v", diags)
+	}
+	if len(sent) != 1 || sent[0] == nil || sent[0].Prefix != "videos/" || sent[0].Auth == nil || sent[0].Auth.Token != "s3cr3t" {
+		t.Errorf("expected update to send the token with the new prefix, got %+v", sent)
+	}
+
+	// A new token is sent when auth_version changes.
+	raw[names.AttrAuthToken] = "rotated"
+	raw[names.AttrAuthVersion] = 2
+	if _, diags = apply(state, raw); diags.HasError() {
+		t.Fatalf("unable to update notification: %v", diags)
+	}
+	if len(sent) != 1 || sent[0] == nil || sent[0].Auth == nil || sent[0].Auth.Token != "rotated" {
+		t.Errorf("expected update to send the rotated token, got %+v", sent)
+	}
+
+	// Changes made outside of Terraform are detected.
+	d := r.Data(nil)
+	d.SetId("tf-events")
+	err = svc.UpdateBucket(ctx, &types.BucketUpdateInput{
+		Bucket: "tf-events",
+		ObjectNotifications: &types.BucketNotificationConfig{
+			WebhookURL: "https://example.com/other",
+			Auth:       &types.BucketNotificationAuth{BasicUsername: "user", BasicPassword: "pass"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceBucketNotificationRead(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to read notification: %v", diags)
+	}
+	if got := d.Get(names.AttrWebhookURL).(string); got != "https://example.com/other" {
+		t.Errorf("webhook_url = %q, want %q", got, "https://example.com/other")
+	}
+	if got := d.Get(names.AttrEvents).(*schema.Set).Len(); got != 0 {
+		t.Errorf("got %d events, want 0", got)
+	}
+	if got := d.Get(names.AttrBasicAuthUsername).(string); got != "user" {
+		t.Errorf("basic_auth_username = %q, want %q", got, "user")
+	}
+	if got := d.Get(names.AttrBasicAuthPassword).(string); got != "" {
+		t.Errorf("expected basic_auth_password not to be read back, got %q", got)
+	}
+
+	if diags := resourceBucketNotificationDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to delete notification: %v", diags)
+	}
+	d.SetId("tf-events")
+	if diags := resourceBucketNotificationRead(ctx, d, svc); diags.HasError() || d.Id() != "" {
+		t.Errorf("expected deleted notification to be removed from state, got id=%q diags=%v", d.Id(), diags)
+	}
+}
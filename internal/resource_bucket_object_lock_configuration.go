@@ -0,0 +1,218 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func resourceTigrisBucketObjectLockConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris bucket object lock configuration resource. This can be used to manage the default " +
+			"retention of new objects in a bucket that has object lock enabled.",
+		CreateWithoutTimeout: resourceBucketObjectLockConfigurationCreate,
+		ReadWithoutTimeout:   resourceBucketObjectLockConfigurationRead,
+		UpdateWithoutTimeout: resourceBucketObjectLockConfigurationUpdate,
+		DeleteWithoutTimeout: resourceBucketObjectLockConfigurationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Tigris bucket. It must have object lock enabled.",
+			},
+			names.AttrDefaultRetention: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "The default retention applied to new objects in the bucket.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						names.AttrMode: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(objectLockRetentionMode_Values(), false),
+							Description:  "The retention mode. Objects in `GOVERNANCE` mode can be deleted by users with special permissions, objects in `COMPLIANCE` mode cannot be deleted by anyone until the retention period ends.",
+						},
+						names.AttrDays: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							ExactlyOneOf: []string{defaultRetentionPath(names.AttrDays), defaultRetentionPath(names.AttrYears)},
+							Description:  "The number of days to retain new objects for.",
+						},
+						names.AttrYears: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							ExactlyOneOf: []string{defaultRetentionPath(names.AttrDays), defaultRetentionPath(names.AttrYears)},
+							Description:  "The number of years to retain new objects for.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBucketObjectLockConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+
+	objectLock, err := svc.GetBucketObjectLock(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket object lock configuration, %w", err))
+	}
+	if !objectLock.Enabled {
+		return diag.FromErr(fmt.Errorf("object lock is not enabled on bucket %q, set %s on the tigris_bucket resource when the bucket is created", bucketName, names.AttrObjectLockEnabled))
+	}
+
+	tflog.Info(ctx, "Creating bucket object lock configuration", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketObjectLock(ctx, bucketName, expandObjectLockRetention(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create bucket object lock configuration, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket object lock configuration created successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId(bucketName)
+
+	return resourceBucketObjectLockConfigurationRead(ctx, d, meta)
+}
+
+func resourceBucketObjectLockConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Checking bucket existence", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if !exists {
+		tflog.Warn(ctx, "Bucket not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+
+	d.Set(names.AttrBucket, bucketName)
+
+	objectLock, err := svc.GetBucketObjectLock(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket object lock configuration, %w", err))
+	}
+	if !objectLock.Enabled || objectLock.DefaultRetention == nil {
+		tflog.Warn(ctx, "Bucket default retention not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+
+	retention := map[string]interface{}{
+		names.AttrMode: string(objectLock.DefaultRetention.Mode),
+	}
+	if objectLock.DefaultRetention.Days > 0 {
+		retention[names.AttrDays] = int(objectLock.DefaultRetention.Days)
+	}
+	if objectLock.DefaultRetention.Years > 0 {
+		retention[names.AttrYears] = int(objectLock.DefaultRetention.Years)
+	}
+	d.Set(names.AttrDefaultRetention, []interface{}{retention})
+
+	return nil
+}
+
+func resourceBucketObjectLockConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Updating bucket object lock configuration", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketObjectLock(ctx, bucketName, expandObjectLockRetention(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to update bucket object lock configuration, %w", err))
+	}
+
+	return resourceBucketObjectLockConfigurationRead(ctx, d, meta)
+}
+
+func resourceBucketObjectLockConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	// Object lock stays enabled on the bucket, only the default retention
+	// is removed.
+	tflog.Info(ctx, "Deleting bucket default retention", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketObjectLock(ctx, bucketName, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to delete bucket object lock configuration, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket default retention deleted successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId("")
+	return nil
+}
+
+func expandObjectLockRetention(d *schema.ResourceData) *types.ObjectLockRetention {
+	return &types.ObjectLockRetention{
+		Mode:  types.ObjectLockRetentionMode(d.Get(defaultRetentionPath(names.AttrMode)).(string)),
+		Days:  int32(d.Get(defaultRetentionPath(names.AttrDays)).(int)),
+		Years: int32(d.Get(defaultRetentionPath(names.AttrYears)).(int)),
+	}
+}
+
+func defaultRetentionPath(attr string) string {
+	return fmt.Sprintf("%s.0.%s", names.AttrDefaultRetention, attr)
+}
+
+func objectLockRetentionMode_Values() []string {
+	var mode types.ObjectLockRetentionMode
+
+	values := []string{}
+	for _, value := range mode.Values() {
+		values = append(values, string(value))
+	}
+
+	return values
+}
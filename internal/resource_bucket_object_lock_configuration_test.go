@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+)
+
+func TestResourceBucketObjectLockConfiguration(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	for bucketName, enabled := range map[string]bool{"tf-locked": true, "tf-unlocked": false} {
+		bucket := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+			names.AttrBucket:            bucketName,
+			names.AttrObjectLockEnabled: enabled,
+		})
+		if diags := resourceBucketCreate(ctx, bucket, svc); diags.HasError() {
+			t.Fatalf("unable to create bucket: %v", diags)
+		}
+		if got := bucket.Get(names.AttrObjectLockEnabled).(bool); got != enabled {
+			t.Errorf("%s: object_lock_enabled = %t, want %t", bucketName, got, enabled)
+		}
+
+		// Object lock cannot be disabled once it is enabled.
+		_, err := resourceTigrisBucket().Diff(ctx, bucket.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			names.AttrBucket: bucketName,
+		}), svc)
+		if enabled && (err == nil || !strings.Contains(err.Error(), "object lock cannot be disabled")) {
+			t.Errorf("%s: expected error disabling object lock, got %v", bucketName, err)
+		}
+		if !enabled && err != nil {
+			t.Errorf("%s: unexpected error %v", bucketName, err)
+		}
+	}
+
+	raw := map[string]interface{}{
+		names.AttrBucket: "tf-unlocked",
+		names.AttrDefaultRetention: []interface{}{
+			map[string]interface{}{names.AttrMode: "COMPLIANCE", names.AttrDays: 30},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucketObjectLockConfiguration().Schema, raw)
+	diags := resourceBucketObjectLockConfigurationCreate(ctx, d, svc)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "object lock is not enabled") {
+		t.Fatalf("expected object lock not enabled error, got %v", diags)
+	}
+
+	raw[names.AttrBucket] = "tf-locked"
+	d = schema.TestResourceDataRaw(t, resourceTigrisBucketObjectLockConfiguration().Schema, raw)
+	if diags := resourceBucketObjectLockConfigurationCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create object lock configuration: %v", diags)
+	}
+
+	imported := resourceTigrisBucketObjectLockConfiguration().Data(nil)
+	imported.SetId("tf-locked")
+	if diags := resourceBucketObjectLockConfigurationRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to import object lock configuration: %v", diags)
+	}
+	if got := imported.Get(defaultRetentionPath(names.AttrMode)).(string); got != "COMPLIANCE" {
+		t.Errorf("mode = %q, want %q", got, "COMPLIANCE")
+	}
+	if got := imported.Get(defaultRetentionPath(names.AttrDays)).(int); got != 30 {
+		t.Errorf("days = %d, want 30", got)
+	}
+
+	// Destroying the resource removes the default retention but keeps the lock.
+	if diags := resourceBucketObjectLockConfigurationDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to delete object lock configuration: %v", diags)
+	}
+	objectLock, err := svc.GetBucketObjectLock(ctx, "tf-locked")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !objectLock.Enabled || objectLock.DefaultRetention != nil {
+		t.Errorf("unexpected object lock after delete: %+v", objectLock)
+	}
+	if diags := resourceBucketObjectLockConfigurationRead(ctx, imported, svc); diags.HasError() || imported.Id() != "" {
+		t.Errorf("expected deleted configuration to be removed from state, got id=%q diags=%v", imported.Id(), diags)
+	}
+}
@@ -0,0 +1,370 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+)
+
+// policyActions are the S3 actions a bucket policy can allow or deny.
+var policyActions = []string{
+	"s3:AbortMultipartUpload",
+	"s3:BypassGovernanceRetention",
+	"s3:CreateBucket",
+	"s3:DeleteBucket",
+	"s3:DeleteBucketPolicy",
+	"s3:DeleteBucketWebsite",
+	"s3:DeleteObject",
+	"s3:DeleteObjectTagging",
+	"s3:DeleteObjectVersion",
+	"s3:DeleteObjectVersionTagging",
+	"s3:GetBucketAcl",
+	"s3:GetBucketCORS",
+	"s3:GetBucketLocation",
+	"s3:GetBucketLogging",
+	"s3:GetBucketNotification",
+	"s3:GetBucketObjectLockConfiguration",
+	"s3:GetBucketPolicy",
+	"s3:GetBucketTagging",
+	"s3:GetBucketVersioning",
+	"s3:GetBucketWebsite",
+	"s3:GetEncryptionConfiguration",
+	"s3:GetLifecycleConfiguration",
+	"s3:GetObject",
+	"s3:GetObjectAcl",
+	"s3:GetObjectAttributes",
+	"s3:GetObjectLegalHold",
+	"s3:GetObjectRetention",
+	"s3:GetObjectTagging",
+	"s3:GetObjectVersion",
+	"s3:GetObjectVersionAcl",
+	"s3:GetObjectVersionAttributes",
+	"s3:GetObjectVersionTagging",
+	"s3:ListAllMyBuckets",
+	"s3:ListBucket",
+	"s3:ListBucketMultipartUploads",
+	"s3:ListBucketVersions",
+	"s3:ListMultipartUploadParts",
+	"s3:PutBucketAcl",
+	"s3:PutBucketCORS",
+	"s3:PutBucketLogging",
+	"s3:PutBucketNotification",
+	"s3:PutBucketObjectLockConfiguration",
+	"s3:PutBucketPolicy",
+	"s3:PutBucketTagging",
+	"s3:PutBucketVersioning",
+	"s3:PutBucketWebsite",
+	"s3:PutEncryptionConfiguration",
+	"s3:PutLifecycleConfiguration",
+	"s3:PutObject",
+	"s3:PutObjectAcl",
+	"s3:PutObjectLegalHold",
+	"s3:PutObjectRetention",
+	"s3:PutObjectTagging",
+	"s3:PutObjectVersionAcl",
+	"s3:PutObjectVersionTagging",
+	"s3:RestoreObject",
+}
+
+func resourceTigrisBucketPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris bucket policy resource. This can be used to grant or deny access to a bucket " +
+			"and its objects with a JSON policy document.",
+		CreateWithoutTimeout: resourceBucketPolicyCreate,
+		ReadWithoutTimeout:   resourceBucketPolicyRead,
+		UpdateWithoutTimeout: resourceBucketPolicyUpdate,
+		DeleteWithoutTimeout: resourceBucketPolicyDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Tigris bucket.",
+			},
+			names.AttrPolicy: {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validBucketPolicy,
+				DiffSuppressFunc: suppressEquivalentPolicy,
+				Description: "The JSON policy document of the bucket. Differences in whitespace, key order, list order and " +
+					"single values written as lists do not cause a diff.",
+			},
+		},
+	}
+}
+
+func resourceBucketPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+
+	tflog.Info(ctx, "Creating bucket policy", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketPolicy(ctx, bucketName, d.Get(names.AttrPolicy).(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create bucket policy, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket policy created successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId(bucketName)
+
+	return resourceBucketPolicyRead(ctx, d, meta)
+}
+
+func resourceBucketPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Checking bucket existence", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if !exists {
+		tflog.Warn(ctx, "Bucket not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+
+	d.Set(names.AttrBucket, bucketName)
+
+	policy, err := svc.GetBucketPolicy(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket policy, %w", err))
+	}
+	if policy == "" {
+		tflog.Warn(ctx, "Bucket policy not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+
+	// Keep the policy as it is written when Tigris returns it reformatted.
+	if current := d.Get(names.AttrPolicy).(string); equivalentPolicies(current, policy) {
+		policy = current
+	}
+	d.Set(names.AttrPolicy, policy)
+
+	return nil
+}
+
+func resourceBucketPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Updating bucket policy", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketPolicy(ctx, bucketName, d.Get(names.AttrPolicy).(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to update bucket policy, %w", err))
+	}
+
+	return resourceBucketPolicyRead(ctx, d, meta)
+}
+
+func resourceBucketPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Deleting bucket policy", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.DeleteBucketPolicy(ctx, bucketName); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to delete bucket policy, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket policy deleted successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId("")
+	return nil
+}
+
+// validBucketPolicy validates that the policy is a JSON document whose
+// statements have an effect and only known actions.
+func validBucketPolicy(v interface{}, k string) (ws []string, errors []error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &doc); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON policy: %s", k, err))
+		return
+	}
+
+	statements, ok := doc["Statement"].([]interface{})
+	if !ok {
+		if statement, ok := doc["Statement"].(map[string]interface{}); ok {
+			statements = []interface{}{statement}
+		}
+	}
+	if len(statements) == 0 {
+		errors = append(errors, fmt.Errorf("%q must contain at least one Statement", k))
+		return
+	}
+
+	for i, s := range statements {
+		statement, ok := s.(map[string]interface{})
+		if !ok {
+			errors = append(errors, fmt.Errorf("%q statement %d must be an object", k, i))
+			continue
+		}
+
+		if effect := statement["Effect"]; effect != "Allow" && effect != "Deny" {
+			errors = append(errors, fmt.Errorf("%q statement %d must have an Effect of \"Allow\" or \"Deny\", got %v", k, i, effect))
+		}
+
+		actions, ok := statement["Action"]
+		if !ok {
+			actions, ok = statement["NotAction"]
+		}
+		if !ok {
+			errors = append(errors, fmt.Errorf("%q statement %d must have an Action or NotAction", k, i))
+			continue
+		}
+
+		values, ok := actions.([]interface{})
+		if !ok {
+			values = []interface{}{actions}
+		}
+		for _, value := range values {
+			action, ok := value.(string)
+			if !ok {
+				errors = append(errors, fmt.Errorf("%q statement %d contains an action that is not a string: %v", k, i, value))
+				continue
+			}
+			if !validPolicyAction(action) {
+				errors = append(errors, fmt.Errorf("%q statement %d contains unknown action %q", k, i, action))
+			}
+		}
+	}
+
+	return
+}
+
+// validPolicyAction reports whether the action, which can contain the * and
+// ? wildcards, matches at least one known action. Actions are not case
+// sensitive.
+func validPolicyAction(action string) bool {
+	pattern := strings.ToLower(action)
+	if pattern == "*" {
+		return true
+	}
+
+	for _, known := range policyActions {
+		if ok, _ := path.Match(pattern, strings.ToLower(known)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func suppressEquivalentPolicy(_, old, new string, _ *schema.ResourceData) bool {
+	return equivalentPolicies(old, new)
+}
+
+// equivalentPolicies reports whether both policies are valid JSON documents
+// with the same meaning.
+func equivalentPolicies(a, b string) bool {
+	normalizedA, err := normalizePolicy(a)
+	if err != nil {
+		return false
+	}
+	normalizedB, err := normalizePolicy(b)
+	if err != nil {
+		return false
+	}
+
+	return normalizedA == normalizedB
+}
+
+// normalizePolicy returns the canonical JSON encoding of a policy. Object keys
+// are sorted, lists are sorted, and lists with a single value are replaced by
+// the value, since the order of statements, actions and resources, and
+// writing a single value as a list, do not change the meaning of a policy.
+func normalizePolicy(policy string) (string, error) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return "", err
+	}
+
+	normalized, err := json.Marshal(normalizePolicyValue(doc))
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+func normalizePolicyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalizePolicyValue(value)
+		}
+		return v
+	case []interface{}:
+		if len(v) == 1 {
+			return normalizePolicyValue(v[0])
+		}
+
+		type entry struct {
+			encoded string
+			value   interface{}
+		}
+		entries := make([]entry, 0, len(v))
+		for _, value := range v {
+			value = normalizePolicyValue(value)
+			encoded, _ := json.Marshal(value)
+			entries = append(entries, entry{encoded: string(encoded), value: value})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].encoded < entries[j].encoded
+		})
+
+		values := make([]interface{}, 0, len(entries))
+		for _, e := range entries {
+			values = append(values, e.value)
+		}
+		return values
+	default:
+		return v
+	}
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+const testBucketPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "PublicRead",
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": ["s3:GetObject", "s3:ListBucket"],
+      "Resource": ["arn:aws:s3:::tf-policy", "arn:aws:s3:::tf-policy/*"]
+    }
+  ]
+}`
+
+func TestResourceBucketPolicy(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	if err := svc.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-policy"}); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucketPolicy().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-policy",
+		names.AttrPolicy: testBucketPolicy,
+	})
+	if diags := resourceBucketPolicyCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create policy: %v", diags)
+	}
+
+	imported := resourceTigrisBucketPolicy().Data(nil)
+	imported.SetId("tf-policy")
+	if diags := resourceBucketPolicyRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to import policy: %v", diags)
+	}
+	if !equivalentPolicies(imported.Get(names.AttrPolicy).(string), testBucketPolicy) {
+		t.Errorf("unexpected policy %s", imported.Get(names.AttrPolicy))
+	}
+
+	// A reformatted but equivalent policy is not a change.
+	reformatted := `{"Statement":{"Resource":["arn:aws:s3:::tf-policy/*","arn:aws:s3:::tf-policy"],` +
+		`"Action":["s3:ListBucket","s3:GetObject"],"Principal":["*"],"Effect":"Allow","Sid":"PublicRead"},"Version":"2012-10-17"}`
+	diff, err := resourceTigrisBucketPolicy().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		names.AttrBucket: "tf-policy",
+		names.AttrPolicy: reformatted,
+	}), svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff for an equivalent policy, got %v", diff)
+	}
+
+	// Denying instead of allowing is.
+	diff, err = resourceTigrisBucketPolicy().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		names.AttrBucket: "tf-policy",
+		names.AttrPolicy: strings.Replace(reformatted, `"Allow"`, `"Deny"`, 1),
+	}), svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Error("expected a diff for a changed effect")
+	}
+
+	if diags := resourceBucketPolicyDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to delete policy: %v", diags)
+	}
+	if diags := resourceBucketPolicyRead(ctx, imported, svc); diags.HasError() || imported.Id() != "" {
+		t.Errorf("expected deleted policy to be removed from state, got id=%q diags=%v", imported.Id(), diags)
+	}
+}
+
+func TestEquivalentPolicies(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{`{"Statement":[{"Effect":"Allow","Action":"s3:GetObject"}]}`, `{ "Statement": { "Action": ["s3:GetObject"], "Effect": "Allow" } }`, true},
+		{`{"Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"]}]}`, `{"Statement":[{"Effect":"Allow","Action":["s3:PutObject","s3:GetObject"]}]}`, true},
+		{`{"Statement":[{"Effect":"Allow","Action":"s3:GetObject"}]}`, `{"Statement":[{"Effect":"Deny","Action":"s3:GetObject"}]}`, false},
+		{`{"Statement":[{"Effect":"Allow","Action":"s3:GetObject"}]}`, `not json`, false},
+	}
+
+	for _, tt := range tests {
+		if got := equivalentPolicies(tt.a, tt.b); got != tt.want {
+			t.Errorf("equivalentPolicies(%s, %s) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestValidBucketPolicy(t *testing.T) {
+	tests := []struct {
+		policy string
+		err    string
+	}{
+		{policy: testBucketPolicy},
+		{policy: `{"Statement":{"Effect":"Deny","NotAction":["s3:Get*","s3:List*"]}}`},
+		{policy: `{"Statement":[{"Effect":"Allow","Action":"*"}]}`},
+		{policy: `{"Statement":[{"Effect":"Allow","Action":"S3:getobject"}]}`},
+		{policy: `{"Statement": [`, err: "invalid JSON policy"},
+		{policy: `{"Version":"2012-10-17"}`, err: "at least one Statement"},
+		{policy: `{"Statement":[{"Effect":"Maybe","Action":"s3:GetObject"}]}`, err: `Effect of "Allow" or "Deny"`},
+		{policy: `{"Statement":[{"Effect":"Allow"}]}`, err: "must have an Action or NotAction"},
+		{policy: `{"Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:GetObjekt"]}]}`, err: `unknown action "s3:GetObjekt"`},
+		{policy: `{"Statement":[{"Effect":"Allow","Action":"ec2:*"}]}`, err: `unknown action "ec2:*"`},
+	}
+
+	for _, tt := range tests {
+		_, errs := validBucketPolicy(tt.policy, names.AttrPolicy)
+		if tt.err == "" && len(errs) > 0 {
+			t.Errorf("expected %s to be valid, got %v", tt.policy, errs)
+		}
+		if tt.err != "" && (len(errs) == 0 || !strings.Contains(errs[0].Error(), tt.err)) {
+			t.Errorf("expected %s to fail with %q, got %v", tt.policy, tt.err, errs)
+		}
+	}
+}
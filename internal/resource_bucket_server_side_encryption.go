@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func resourceTigrisBucketServerSideEncryption() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris bucket server-side encryption resource. This can be used to manage the default " +
+			"encryption applied to new objects in a bucket. Tigris encrypts objects with AES256 and has no KMS, " +
+			"so KMS keys and bucket keys are not supported.",
+		CreateWithoutTimeout: resourceBucketServerSideEncryptionCreate,
+		ReadWithoutTimeout:   resourceBucketServerSideEncryptionRead,
+		UpdateWithoutTimeout: resourceBucketServerSideEncryptionUpdate,
+		DeleteWithoutTimeout: resourceBucketServerSideEncryptionDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Tigris bucket.",
+			},
+			names.AttrSSEAlgorithm: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(types.ServerSideEncryptionAES256),
+				ValidateFunc: validation.StringInSlice(serverSideEncryption_Values(), false),
+				Description:  "The server-side encryption algorithm applied to new objects in the bucket. `AES256` is the only algorithm Tigris supports.",
+			},
+		},
+	}
+}
+
+func resourceBucketServerSideEncryptionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+
+	tflog.Info(ctx, "Creating bucket server-side encryption", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketEncryption(ctx, bucketName, expandBucketEncryption(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create bucket server-side encryption, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket server-side encryption created successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId(bucketName)
+
+	return resourceBucketServerSideEncryptionRead(ctx, d, meta)
+}
+
+func resourceBucketServerSideEncryptionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Checking bucket existence", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if !exists {
+		tflog.Warn(ctx, "Bucket not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+
+	d.Set(names.AttrBucket, bucketName)
+
+	encryption, err := svc.GetBucketEncryption(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket server-side encryption, %w", err))
+	}
+	if encryption == nil {
+		tflog.Warn(ctx, "Bucket server-side encryption not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(names.AttrSSEAlgorithm, string(encryption.Algorithm))
+
+	return nil
+}
+
+func resourceBucketServerSideEncryptionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Updating bucket server-side encryption", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.PutBucketEncryption(ctx, bucketName, expandBucketEncryption(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to update bucket server-side encryption, %w", err))
+	}
+
+	return resourceBucketServerSideEncryptionRead(ctx, d, meta)
+}
+
+func resourceBucketServerSideEncryptionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Deleting bucket server-side encryption", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	if err := svc.DeleteBucketEncryption(ctx, bucketName); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to delete bucket server-side encryption, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket server-side encryption deleted successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId("")
+	return nil
+}
+
+func expandBucketEncryption(d *schema.ResourceData) *types.BucketEncryption {
+	return &types.BucketEncryption{
+		Algorithm: types.ServerSideEncryption(d.Get(names.AttrSSEAlgorithm).(string)),
+	}
+}
+
+func serverSideEncryption_Values() []string {
+	var sse types.ServerSideEncryption
+
+	values := []string{}
+	for _, value := range sse.Values() {
+		values = append(values, string(value))
+	}
+
+	return values
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func TestResourceBucketServerSideEncryption(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	if err := svc.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-encrypted"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tigris has no KMS.
+	diags := resourceTigrisBucketServerSideEncryption().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		names.AttrBucket:       "tf-encrypted",
+		names.AttrSSEAlgorithm: "aws:kms",
+	}))
+	if !diags.HasError() {
+		t.Error("expected aws:kms to be rejected")
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucketServerSideEncryption().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-encrypted",
+	})
+	if diags := resourceBucketServerSideEncryptionCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create server-side encryption: %v", diags)
+	}
+
+	imported := resourceTigrisBucketServerSideEncryption().Data(nil)
+	imported.SetId("tf-encrypted")
+	if diags := resourceBucketServerSideEncryptionRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to import server-side encryption: %v", diags)
+	}
+	if got := imported.Get(names.AttrSSEAlgorithm).(string); got != "AES256" {
+		t.Errorf("sse_algorithm = %q, want %q", got, "AES256")
+	}
+
+	// An algorithm set outside of Terraform shows up as drift.
+	if err := svc.PutBucketEncryption(ctx, "tf-encrypted", &types.BucketEncryption{Algorithm: "aws:kms"}); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceBucketServerSideEncryptionRead(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to read server-side encryption: %v", diags)
+	}
+	if got := d.Get(names.AttrSSEAlgorithm).(string); got != "aws:kms" {
+		t.Errorf("sse_algorithm after drift = %q, want %q", got, "aws:kms")
+	}
+
+	if diags := resourceBucketServerSideEncryptionDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to delete server-side encryption: %v", diags)
+	}
+	if diags := resourceBucketServerSideEncryptionRead(ctx, imported, svc); diags.HasError() || imported.Id() != "" {
+		t.Errorf("expected deleted configuration to be removed from state, got id=%q diags=%v", imported.Id(), diags)
+	}
+}
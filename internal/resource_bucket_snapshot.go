@@ -0,0 +1,185 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/YakDriver/regexache"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+)
+
+func resourceTigrisBucketSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris bucket snapshot resource. This can be used to take a named point-in-time snapshot " +
+			"of a bucket that has snapshots enabled. Tigris has no API to delete a snapshot, so destroying the resource never " +
+			"removes the snapshot data, it only removes the snapshot from the Terraform state. There is no option to delete " +
+			"the snapshot data on destroy.",
+		CreateWithoutTimeout: resourceBucketSnapshotCreate,
+		ReadWithoutTimeout:   resourceBucketSnapshotRead,
+		DeleteWithoutTimeout: resourceBucketSnapshotDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Tigris bucket to take the snapshot of.",
+			},
+			names.AttrName: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validBucketSnapshotName,
+				Description: "The name of the snapshot. It can contain from 1 to 63 letters, digits, periods, hyphens " +
+					"and underscores.",
+			},
+			names.AttrVersion: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version that identifies the snapshot.",
+			},
+			names.AttrCreationDate: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the snapshot was taken, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func resourceBucketSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+	name := d.Get(names.AttrName).(string)
+
+	tflog.Info(ctx, "Creating bucket snapshot", map[string]interface{}{
+		"bucket_name":   bucketName,
+		"snapshot_name": name,
+	})
+
+	snapshot, err := svc.CreateBucketSnapshot(ctx, bucketName, name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create bucket snapshot, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket snapshot created successfully", map[string]interface{}{
+		"bucket_name":      bucketName,
+		"snapshot_version": snapshot.Version,
+	})
+
+	d.SetId(bucketSnapshotID(bucketName, snapshot.Version))
+
+	return resourceBucketSnapshotRead(ctx, d, meta)
+}
+
+func resourceBucketSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName, version, err := parseBucketSnapshotID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, "Checking bucket existence", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if !exists {
+		tflog.Warn(ctx, "Bucket not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+
+	tflog.Info(ctx, "Fetching bucket snapshots", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	snapshots, err := svc.ListBucketSnapshots(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to list bucket snapshots, %w", err))
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Version != version {
+			continue
+		}
+
+		d.Set(names.AttrBucket, bucketName)
+		d.Set(names.AttrName, snapshot.Name)
+		d.Set(names.AttrVersion, snapshot.Version)
+		d.Set(names.AttrCreationDate, snapshot.CreationDate.Format(time.RFC3339))
+
+		return nil
+	}
+
+	tflog.Warn(ctx, "Bucket snapshot not found, removing from state", map[string]interface{}{
+		"id": d.Id(),
+	})
+
+	d.SetId("")
+	return nil
+}
+
+func resourceBucketSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Tigris has no API to delete a snapshot, so it is always kept
+	tflog.Warn(ctx, "Removing bucket snapshot from state, the snapshot is retained", map[string]interface{}{
+		"id": d.Id(),
+	})
+
+	d.SetId("")
+	return nil
+}
+
+// validBucketSnapshotName validates the snapshot name, which is sent in the
+// X-Tigris-Snapshot header as "true; name=<name>" and listed back in the
+// same form, so it cannot contain separators.
+func validBucketSnapshotName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if len(value) == 0 || len(value) > 63 {
+		errors = append(errors, fmt.Errorf("%q must contain from 1 to 63 characters", k))
+	}
+	if !regexache.MustCompile(`^[0-9A-Za-z._-]*$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("only alphanumeric characters, periods, hyphens and underscores allowed in %q", k))
+	}
+
+	return
+}
+
+// bucketSnapshotID returns the resource ID of a bucket snapshot.
+func bucketSnapshotID(bucketName, version string) string {
+	return bucketName + "/" + version
+}
+
+// parseBucketSnapshotID parses a resource ID of the form <bucket>/<version>.
+func parseBucketSnapshotID(id string) (string, string, error) {
+	bucketName, version, ok := strings.Cut(id, "/")
+	if !ok || bucketName == "" || version == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected <bucket>/<version>", id)
+	}
+
+	return bucketName, version, nil
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+)
+
+func TestResourceBucketSnapshot(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	for bucketName, enableSnapshot := range map[string]bool{"tf-snapshots": true, "tf-no-snapshots": false} {
+		bucket := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+			names.AttrBucket:         bucketName,
+			names.AttrEnableSnapshot: enableSnapshot,
+		})
+		if diags := resourceBucketCreate(ctx, bucket, svc); diags.HasError() {
+			t.Fatalf("unable to create bucket: %v", diags)
+		}
+		if got := bucket.Get(names.AttrEnableSnapshot).(bool); got != enableSnapshot {
+			t.Errorf("%s: enable_snapshot = %t, want %t", bucketName, got, enableSnapshot)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucketSnapshot().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-snapshots",
+		names.AttrName:   "before-migration",
+	})
+	if diags := resourceBucketSnapshotCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create snapshot: %v", diags)
+	}
+	if d.Id() == "" || d.Get(names.AttrVersion).(string) == "" || d.Get(names.AttrCreationDate).(string) == "" {
+		t.Fatalf("snapshot attributes not set: id=%q version=%q", d.Id(), d.Get(names.AttrVersion))
+	}
+
+	// Imported snapshots are read back from their ID.
+	imported := resourceTigrisBucketSnapshot().Data(nil)
+	imported.SetId(d.Id())
+	if diags := resourceBucketSnapshotRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to read snapshot: %v", diags)
+	}
+	if got := imported.Get(names.AttrName).(string); got != "before-migration" {
+		t.Errorf("read name = %q, want %q", got, "before-migration")
+	}
+
+	ds := schema.TestResourceDataRaw(t, dataSourceTigrisBucketSnapshots().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-snapshots",
+	})
+	if diags := dataSourceBucketSnapshotsRead(ctx, ds, svc); diags.HasError() {
+		t.Fatalf("unable to list snapshots: %v", diags)
+	}
+	if got := ds.Get(names.AttrSnapshots + ".#").(int); got != 1 {
+		t.Errorf("listed %d snapshots, want 1", got)
+	}
+
+	// Destroying the resource keeps the snapshot.
+	if diags := resourceBucketSnapshotDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to delete snapshot: %v", diags)
+	}
+	snapshots, err := svc.ListBucketSnapshots(ctx, "tf-snapshots")
+	if err != nil || len(snapshots) != 1 {
+		t.Errorf("snapshot should be retained, got %v, %v", snapshots, err)
+	}
+
+	failed := schema.TestResourceDataRaw(t, resourceTigrisBucketSnapshot().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-no-snapshots",
+	})
+	if diags := resourceBucketSnapshotCreate(ctx, failed, svc); !diags.HasError() {
+		t.Error("expected snapshot of a bucket without snapshots enabled to fail")
+	}
+}
+
+func TestParseBucketSnapshotID(t *testing.T) {
+	bucketName, version, err := parseBucketSnapshotID(bucketSnapshotID("my-bucket", "1751631910169675092"))
+	if err != nil || bucketName != "my-bucket" || version != "1751631910169675092" {
+		t.Errorf("parseBucketSnapshotID() = %q, %q, %v", bucketName, version, err)
+	}
+
+	for _, id := range []string{"", "my-bucket", "my-bucket/", "/1751631910169675092"} {
+		if _, _, err := parseBucketSnapshotID(id); err == nil {
+			t.Errorf("parseBucketSnapshotID(%q) returned no error", id)
+		}
+	}
+}
+
+func TestValidBucketSnapshotName(t *testing.T) {
+	for _, name := range []string{"before-migration", "v1.2.3", "nightly_2030_01_01", strings.Repeat("a", 63)} {
+		if _, errs := validBucketSnapshotName(name, names.AttrName); len(errs) > 0 {
+			t.Errorf("validBucketSnapshotName(%q) = %v, want no errors", name, errs)
+		}
+	}
+	for _, name := range []string{"", "a;name=b", "a=b", "a,b", "a b", "a\nb", strings.Repeat("a", 64)} {
+		if _, errs := validBucketSnapshotName(name, names.AttrName); len(errs) == 0 {
+			t.Errorf("validBucketSnapshotName(%q) returned no errors", name)
+		}
+	}
+}
@@ -0,0 +1,925 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/memory"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func TestResourceBucketObjectRegions(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:        "tf-regions",
+		names.AttrObjectRegions: []interface{}{"fra", "ams"},
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+
+	metadata, err := svc.GetBucketMetadata(ctx, "tf-regions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.ObjectRegions != "ams,fra" {
+		t.Errorf("object regions = %q, want %q", metadata.ObjectRegions, "ams,fra")
+	}
+
+	// Imported buckets read the regions back from the metadata.
+	imported := resourceTigrisBucket().Data(nil)
+	imported.SetId("tf-regions")
+	if diags := resourceBucketRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to read bucket: %v", diags)
+	}
+	got := expandStringSet(imported.Get(names.AttrObjectRegions).(*schema.Set))
+	if !reflect.DeepEqual(got, []string{"ams", "fra"}) {
+		t.Errorf("read object regions = %v", got)
+	}
+}
+
+func TestResourceBucketCacheControl(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:       "tf-cache-control",
+		names.AttrCacheControl: "public, max-age=3600",
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+
+	metadata, err := svc.GetBucketMetadata(ctx, "tf-cache-control")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.CacheControl != "public, max-age=3600" {
+		t.Errorf("cache control = %q, want %q", metadata.CacheControl, "public, max-age=3600")
+	}
+
+	// A default changed outside of Terraform shows up as drift.
+	cacheControl := "no-store"
+	if err := svc.UpdateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-cache-control", CacheControl: &cacheControl}); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceBucketRead(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to read bucket: %v", diags)
+	}
+	if got := d.Get(names.AttrCacheControl).(string); got != "no-store" {
+		t.Errorf("read cache control = %q, want %q", got, "no-store")
+	}
+
+	// Invalid directives are rejected at plan time.
+	diags := resourceTigrisBucket().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		names.AttrBucket:       "tf-cache-control",
+		names.AttrCacheControl: "public, max-age=forever",
+	}))
+	if !diags.HasError() {
+		t.Error("expected invalid cache control to be rejected")
+	}
+}
+
+func TestResourceBucketMetadata(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:       "tf-metadata",
+		names.AttrStorageClass: "STANDARD_IA",
+		names.AttrConsistency:  "strict",
+		names.AttrTags:         map[string]interface{}{"env": "test", "team": "storage"},
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+
+	metadata, err := svc.GetBucketMetadata(ctx, "tf-metadata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.StorageClass != types.StorageClassStandardIA {
+		t.Errorf("storage class = %q, want %q", metadata.StorageClass, types.StorageClassStandardIA)
+	}
+	if metadata.Consistency != types.ConsistencyStrict {
+		t.Errorf("consistency = %q, want %q", metadata.Consistency, types.ConsistencyStrict)
+	}
+
+	// Imported buckets read the attributes back from the metadata.
+	imported := resourceTigrisBucket().Data(nil)
+	imported.SetId("tf-metadata")
+	if diags := resourceBucketRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to read bucket: %v", diags)
+	}
+	if got := imported.Get(names.AttrStorageClass).(string); got != "STANDARD_IA" {
+		t.Errorf("read storage class = %q", got)
+	}
+	if got := imported.Get(names.AttrConsistency).(string); got != "strict" {
+		t.Errorf("read consistency = %q", got)
+	}
+	wantTags := map[string]interface{}{"env": "test", "team": "storage"}
+	if got := imported.Get(names.AttrTags).(map[string]interface{}); !reflect.DeepEqual(got, wantTags) {
+		t.Errorf("read tags = %v, want %v", got, wantTags)
+	}
+
+	// Tags removed outside of Terraform show up as drift.
+	if err := svc.UpdateBucketTags(ctx, "tf-metadata", nil); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceBucketRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to read bucket: %v", diags)
+	}
+	if got := imported.Get(names.AttrTags).(map[string]interface{}); len(got) != 0 {
+		t.Errorf("read tags after removal = %v, want none", got)
+	}
+}
+
+// roundTripFunc adapts a function to an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestResourceBucketCreateNotVisible(t *testing.T) {
+	backend := memory.NewBackend()
+
+	// The created bucket never shows up.
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodHead {
+			return backend.RoundTrip(req)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{},
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	})
+	svc, err := NewClient(memory.Endpoint, "memory", "memory",
+		WithTransport(transport),
+		WithRetryBackoff(time.Millisecond, time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:        "tf-invisible",
+		names.AttrObjectRegions: []interface{}{"fra"},
+	})
+	diags := resourceBucketCreate(context.Background(), d, svc)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, `bucket "tf-invisible" not visible after create`) {
+		t.Errorf("expected not visible error, got %v", diags)
+	}
+}
+
+func TestValidCacheControl(t *testing.T) {
+	valid := []string{
+		"public, max-age=3600",
+		"no-store",
+		"max-age=0, must-revalidate",
+		"public, max-age=31536000, immutable",
+		`private="Set-Cookie, Authorization", s-maxage=60`,
+		"Public, Max-Age=60",
+	}
+	invalid := []string{
+		"",
+		"public,",
+		"max-age",
+		"max-age=-1",
+		"max-age=ten",
+		"public, max-age=60, max-age=120",
+		"public, private",
+		"no-store=1",
+		"no-cache=Set-Cookie",
+		"cache-forever",
+	}
+
+	for _, value := range valid {
+		if _, errs := validCacheControl(value, names.AttrCacheControl); len(errs) > 0 {
+			t.Errorf("validCacheControl(%q) = %v, want no errors", value, errs)
+		}
+	}
+	for _, value := range invalid {
+		if _, errs := validCacheControl(value, names.AttrCacheControl); len(errs) == 0 {
+			t.Errorf("validCacheControl(%q) returned no errors", value)
+		}
+	}
+}
+
+func TestValidBucketTags(t *testing.T) {
+	tooMany := map[string]interface{}{}
+	for i := 0; i < 51; i++ {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	tests := []struct {
+		tags    map[string]interface{}
+		wantErr bool
+	}{
+		{tags: map[string]interface{}{"env": "prod", "empty": ""}},
+		{tags: map[string]interface{}{strings.Repeat("k", 128): strings.Repeat("v", 256)}},
+		{tags: map[string]interface{}{"": "value"}, wantErr: true},
+		{tags: map[string]interface{}{strings.Repeat("k", 129): "value"}, wantErr: true},
+		{tags: map[string]interface{}{"key": strings.Repeat("v", 257)}, wantErr: true},
+		{tags: map[string]interface{}{"aws:createdBy": "me"}, wantErr: true},
+		{tags: tooMany, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		_, errs := validBucketTags(tt.tags, names.AttrTags)
+		if (len(errs) > 0) != tt.wantErr {
+			t.Errorf("validBucketTags(%v) = %v, want error %t", tt.tags, errs, tt.wantErr)
+		}
+	}
+}
+
+func TestValidBucketName(t *testing.T) {
+	valid := []string{"abc", "my-bucket", "my.bucket.name", "1bucket", "a-b.c-d", strings.Repeat("a", 63), "1.2.3.a"}
+	invalid := []string{"", "ab", strings.Repeat("a", 64), "My-Bucket", "bucket_name", "192.168.1.1", ".bucket", "bucket.", "my..bucket", "bucket!"}
+
+	for _, name := range valid {
+		if err := validBucketName(name); err != nil {
+			t.Errorf("validBucketName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range invalid {
+		if err := validBucketName(name); err == nil {
+			t.Errorf("validBucketName(%q) = nil, want error", name)
+		}
+	}
+}
+
+func FuzzValidBucketName(f *testing.F) {
+	for _, seed := range []string{"abc", "my-bucket", "my.bucket", "10.0.0.1", "1.2.3.4.5", ".a.", "a..b", "ÄBC", strings.Repeat("z", 64)} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, name string) {
+		err := validBucketName(name)
+		want := referenceBucketNameValid(name)
+		if (err == nil) != want {
+			t.Fatalf("validBucketName(%q) = %v, reference model valid = %t", name, err, want)
+		}
+	})
+}
+
+// referenceBucketNameValid is a regex-free model of the DNS-compliant bucket
+// naming rules enforced by validBucketName.
+func referenceBucketNameValid(name string) bool {
+	if len(name) < 3 || len(name) > 63 {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '-' && c != '.' {
+			return false
+		}
+	}
+	if name[0] == '.' || name[len(name)-1] == '.' {
+		return false
+	}
+
+	labels := strings.Split(name, ".")
+	for _, label := range labels {
+		if label == "" {
+			return false
+		}
+	}
+
+	return !isDottedQuad(labels)
+}
+
+func isDottedQuad(labels []string) bool {
+	if len(labels) != 4 {
+		return false
+	}
+	for _, label := range labels {
+		if len(label) > 3 {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			if label[i] < '0' || label[i] > '9' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func TestResourceBucketFork(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	source := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:         "tf-fork-source",
+		names.AttrEnableSnapshot: true,
+	})
+	if diags := resourceBucketCreate(ctx, source, svc); diags.HasError() {
+		t.Fatalf("unable to create source bucket: %v", diags)
+	}
+	snapshot, err := svc.CreateBucketSnapshot(ctx, "tf-fork-source", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		raw     map[string]interface{}
+		wantErr string
+	}{
+		{
+			raw:     map[string]interface{}{names.AttrBucket: "tf-fork", names.AttrForkSourceBucket: "tf-fork-missing"},
+			wantErr: `fork source bucket "tf-fork-missing" does not exist`,
+		},
+		{
+			raw: map[string]interface{}{
+				names.AttrBucket:             "tf-fork",
+				names.AttrForkSourceBucket:   "tf-fork-source",
+				names.AttrForkSourceSnapshot: "1",
+			},
+			wantErr: `snapshot "1" of fork source bucket "tf-fork-source" does not exist`,
+		},
+		{
+			raw: map[string]interface{}{names.AttrBucket: "tf-fork", names.AttrForkSourceBucket: "tf-fork-source"},
+		},
+		{
+			raw: map[string]interface{}{
+				names.AttrBucket:             "tf-fork-snapshot",
+				names.AttrForkSourceBucket:   "tf-fork-source",
+				names.AttrForkSourceSnapshot: snapshot.Version,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := resourceTigrisBucket().Diff(ctx, nil, terraform.NewResourceConfigRaw(tt.raw), svc)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("plan of %v: expected error %q, got %v", tt.raw, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("plan of %v: unexpected error %v", tt.raw, err)
+		}
+
+		d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, tt.raw)
+		if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+			t.Fatalf("unable to create fork: %v", diags)
+		}
+
+		want := "tf-fork-source"
+		if v, ok := tt.raw[names.AttrForkSourceSnapshot]; ok {
+			want = bucketSnapshotID("tf-fork-source", v.(string))
+		}
+		if got := d.Get(names.AttrForkSource).(string); got != want {
+			t.Errorf("fork source = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestResourceBucketConnectionAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	// Count the bucket listings.
+	backend := memory.NewBackend()
+	listings := 0
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet && req.URL.Host == "memory.tigris.local" && req.URL.Path == "/" {
+			listings++
+		}
+
+		return backend.RoundTrip(req)
+	})
+	svc, err := NewClient(memory.Endpoint, "memory", "memory", WithTransport(transport), WithEndpointURL(DefaultEndpoint), WithRegion("iad"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-connection",
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+
+	want := map[string]string{
+		names.AttrArn:            "arn:aws:s3:::tf-connection",
+		names.AttrEndpointURL:    DefaultEndpoint,
+		names.AttrVirtualHostURL: "https://tf-connection.fly.storage.tigris.dev",
+		names.AttrS3URI:          "s3://tf-connection",
+		names.AttrRegion:         "iad",
+	}
+	for attr, value := range want {
+		if got := d.Get(attr).(string); got != value {
+			t.Errorf("%s = %q, want %q", attr, got, value)
+		}
+	}
+
+	if _, err := time.Parse(time.RFC3339, d.Get(names.AttrCreationDate).(string)); err != nil {
+		t.Errorf("creation date is not set: %v", err)
+	}
+
+	// Refreshing keeps the creation date without listing the buckets again.
+	listings = 0
+	if diags := resourceBucketRead(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to read bucket: %v", diags)
+	}
+	if listings != 0 || d.Get(names.AttrCreationDate).(string) == "" {
+		t.Errorf("expected creation date to be kept from state, got %d listings", listings)
+	}
+
+	// Imported buckets look the creation date up.
+	imported := resourceTigrisBucket().Data(nil)
+	imported.SetId("tf-connection")
+	if diags := resourceBucketRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to read bucket: %v", diags)
+	}
+	if got := imported.Get(names.AttrCreationDate).(string); listings != 1 || got != d.Get(names.AttrCreationDate).(string) {
+		t.Errorf("imported creation date = %q after %d listings", got, listings)
+	}
+}
+
+// subresourceErrorTransport answers the requests for a bucket subresource
+// with an error before passing other requests on to the next transport.
+type subresourceErrorTransport struct {
+	next        http.RoundTripper
+	subresource string
+	statusCode  int
+	code        string
+}
+
+func (s *subresourceErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !req.URL.Query().Has(s.subresource) {
+		return s.next.RoundTrip(req)
+	}
+
+	body := fmt.Sprintf("<Error><Code>%s</Code><Message>%s</Message></Error>", s.code, http.StatusText(s.statusCode))
+	return &http.Response{
+		StatusCode: s.statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/xml"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestResourceBucketObjectLockNotImplemented(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &subresourceErrorTransport{
+		next:        memory.NewBackend(),
+		subresource: "object-lock",
+		statusCode:  http.StatusNotImplemented,
+		code:        "NotImplemented",
+	}
+	svc, err := NewClient(memory.Endpoint, "memory", "memory", WithTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-locked", ObjectLockEnabled: aws.Bool(true)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Buckets are read on endpoints without object lock support, without
+	// reporting the lock as disabled.
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:            "tf-locked",
+		names.AttrObjectLockEnabled: true,
+	})
+	d.SetId("tf-locked")
+	if diags := resourceBucketRead(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to read bucket: %v", diags)
+	}
+	if d.Id() != "tf-locked" || !d.Get(names.AttrObjectLockEnabled).(bool) {
+		t.Errorf("expected object_lock_enabled to be kept, got id=%q object_lock_enabled=%t", d.Id(), d.Get(names.AttrObjectLockEnabled))
+	}
+
+	// Other errors are not hidden.
+	transport.statusCode, transport.code = http.StatusForbidden, "AccessDenied"
+	if diags := resourceBucketRead(ctx, d, svc); !diags.HasError() {
+		t.Error("expected an object lock error to fail the read")
+	}
+}
+
+func TestResourceBucketRename(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-rename",
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+
+	plan := func(state *terraform.InstanceState, raw map[string]interface{}) (*terraform.InstanceDiff, error) {
+		return resourceTigrisBucket().Diff(ctx, state, terraform.NewResourceConfigRaw(raw), svc)
+	}
+
+	// Renaming an empty bucket replaces it.
+	diff, err := plan(d.State(), map[string]interface{}{names.AttrBucket: "tf-renamed"})
+	if err != nil {
+		t.Fatalf("unexpected error planning rename of empty bucket: %v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Error("expected rename to require replacement")
+	}
+
+	_, err = svc.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String("tf-rename"),
+		Key:    aws.String("object.txt"),
+		Body:   strings.NewReader("content"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Renaming a non-empty bucket fails unless force_destroy is already set.
+	_, err = plan(d.State(), map[string]interface{}{names.AttrBucket: "tf-renamed"})
+	if err == nil || !strings.Contains(err.Error(), `replaces bucket "tf-rename", which is not empty`) {
+		t.Fatalf("expected non-empty bucket error, got %v", err)
+	}
+	_, err = plan(d.State(), map[string]interface{}{names.AttrBucket: "tf-renamed", names.AttrForceDestroy: true})
+	if err == nil {
+		t.Fatal("expected error when force_destroy is set in the same plan as the rename")
+	}
+
+	// In-place changes do not check the bucket contents.
+	if _, err := plan(d.State(), map[string]interface{}{names.AttrBucket: "tf-rename", names.AttrForceDestroy: true}); err != nil {
+		t.Fatalf("unexpected error planning force_destroy: %v", err)
+	}
+
+	if err := d.Set(names.AttrForceDestroy, true); err != nil {
+		t.Fatal(err)
+	}
+	diff, err = plan(d.State(), map[string]interface{}{names.AttrBucket: "tf-renamed", names.AttrForceDestroy: true})
+	if err != nil {
+		t.Fatalf("unexpected error planning rename with force_destroy: %v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Error("expected rename to require replacement")
+	}
+}
+
+func TestResourceBucketStorageClass(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:       "tf-storage-class",
+		names.AttrStorageClass: "STANDARD",
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+
+	tests := []struct {
+		storageClass string
+		requiresNew  bool
+	}{
+		{storageClass: "STANDARD_IA"},
+		{storageClass: "GLACIER_IR"},
+		{storageClass: "GLACIER", requiresNew: true},
+	}
+
+	for _, tt := range tests {
+		diff, err := resourceTigrisBucket().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			names.AttrBucket:       "tf-storage-class",
+			names.AttrStorageClass: tt.storageClass,
+		}), svc)
+		if err != nil {
+			t.Fatalf("unexpected error planning storage class %s: %v", tt.storageClass, err)
+		}
+		if diff.RequiresNew() != tt.requiresNew {
+			t.Errorf("changing storage class to %s: requires new = %t, want %t", tt.storageClass, diff.RequiresNew(), tt.requiresNew)
+		}
+	}
+
+	// Leaving the archive tier replaces the bucket as well.
+	if err := d.Set(names.AttrStorageClass, "GLACIER"); err != nil {
+		t.Fatal(err)
+	}
+	diff, err := resourceTigrisBucket().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		names.AttrBucket:       "tf-storage-class",
+		names.AttrStorageClass: "STANDARD",
+	}), svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.RequiresNew() {
+		t.Error("expected changing storage class from GLACIER to require replacement")
+	}
+}
+
+func TestResourceBucketForceDestroy(t *testing.T) {
+	ctx := context.Background()
+
+	// Keep the multipart uploads that are aborted.
+	backend := memory.NewBackend()
+	var aborted []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodDelete && req.URL.Query().Has("uploadId") {
+			aborted = append(aborted, req.URL.Query().Get("uploadId"))
+		}
+
+		return backend.RoundTrip(req)
+	})
+	svc, err := NewClient(memory.Endpoint, "memory", "memory", WithTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-force-destroy",
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+	if err := svc.PutBucketVersioning(ctx, "tf-force-destroy", types.BucketVersioningStatusEnabled); err != nil {
+		t.Fatal(err)
+	}
+
+	// Leave two object versions, a delete marker and an open multipart
+	// upload in the bucket.
+	for _, content := range []string{"v1", "v2"} {
+		_, err := svc.s3Client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String("tf-force-destroy"),
+			Key:    aws.String("object.txt"),
+			Body:   strings.NewReader(content),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := svc.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String("tf-force-destroy"), Key: aws.String("object.txt")}); err != nil {
+		t.Fatal(err)
+	}
+	upload, err := svc.s3Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String("tf-force-destroy"),
+		Key:    aws.String("large.bin"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without force_destroy the bucket is left alone.
+	if diags := resourceBucketDelete(ctx, d, svc); !diags.HasError() || !strings.Contains(diags[0].Summary, "BucketNotEmpty") {
+		t.Fatalf("expected BucketNotEmpty deleting a non-empty bucket, got %v", diags)
+	}
+	if exists, err := svc.HeadBucket(ctx, "tf-force-destroy"); err != nil || !exists {
+		t.Fatalf("expected bucket to exist, got exists=%t err=%v", exists, err)
+	}
+	if len(aborted) != 0 {
+		t.Errorf("expected no multipart uploads to be aborted, got %v", aborted)
+	}
+
+	if err := d.Set(names.AttrForceDestroy, true); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceBucketDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to destroy bucket: %v", diags)
+	}
+	if exists, err := svc.HeadBucket(ctx, "tf-force-destroy"); err != nil || exists {
+		t.Errorf("expected bucket to be deleted, got exists=%t err=%v", exists, err)
+	}
+	if len(aborted) != 1 || aborted[0] != aws.ToString(upload.UploadId) {
+		t.Errorf("expected multipart upload %s to be aborted, got %v", aws.ToString(upload.UploadId), aborted)
+	}
+}
+
+func TestResourceBucketDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:             "tf-protected",
+		names.AttrDeletionProtection: true,
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+
+	diags := resourceBucketDelete(ctx, d, svc)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "deletion protection enabled") {
+		t.Fatalf("expected deletion protection error, got %v", diags)
+	}
+	if exists, err := svc.HeadBucket(ctx, "tf-protected"); err != nil || !exists {
+		t.Fatalf("expected protected bucket to exist, got exists=%t err=%v", exists, err)
+	}
+
+	// Disabling the protection in the same plan as the replacement is refused.
+	_, err := resourceTigrisBucket().Diff(ctx, d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		names.AttrBucket: "tf-protected-renamed",
+	}), svc)
+	if err == nil || !strings.Contains(err.Error(), "deletion protection enabled") {
+		t.Fatalf("expected deletion protection error on replacement, got %v", err)
+	}
+
+	if err := d.Set(names.AttrDeletionProtection, false); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceBucketDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to delete unprotected bucket: %v", diags)
+	}
+}
+
+func TestResourceBucketAdoptExisting(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	regions := "ams"
+	cacheControl := "no-store"
+	if err := svc.CreateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-adopt"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.UpdateBucket(ctx, &types.BucketUpdateInput{Bucket: "tf-adopt", ObjectRegions: &regions, CacheControl: &cacheControl}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.UpdateBucketTags(ctx, "tf-adopt", map[string]string{"owner": "console"}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := map[string]interface{}{
+		names.AttrBucket:       "tf-adopt",
+		names.AttrCacheControl: "public, max-age=60",
+		names.AttrTags:         map[string]interface{}{"env": "test"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, raw)
+	diags := resourceBucketCreate(ctx, d, svc)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "already exists and is owned by you") {
+		t.Fatalf("expected already owned error, got %v", diags)
+	}
+
+	raw[names.AttrAdoptExisting] = true
+	d = schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, raw)
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to adopt bucket: %v", diags)
+	}
+
+	// The configuration of the adopted bucket is reconciled.
+	if got := d.Get(names.AttrObjectRegions).(*schema.Set).Len(); got != 0 {
+		t.Errorf("object regions = %d, want none", got)
+	}
+	if got := d.Get(names.AttrCacheControl).(string); got != "public, max-age=60" {
+		t.Errorf("cache control = %q", got)
+	}
+	if got := d.Get(names.AttrTags).(map[string]interface{}); !reflect.DeepEqual(got, map[string]interface{}{"env": "test"}) {
+		t.Errorf("tags = %v", got)
+	}
+
+	// Settings that only apply on creation cannot be adopted.
+	d = schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:         "tf-adopt",
+		names.AttrEnableSnapshot: true,
+		names.AttrAdoptExisting:  true,
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); !diags.HasError() || d.Id() != "" {
+		t.Errorf("expected adoption with mismatched snapshots to fail without state, got id=%q diags=%v", d.Id(), diags)
+	}
+
+	// The provider default applies when the attribute is not set.
+	defaulted, err := NewClient(memory.Endpoint, "memory", "memory", WithTransport(svc.httpClient.Transport), WithAdoptExisting(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d = schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-adopt",
+	})
+	if diags := resourceBucketCreate(ctx, d, defaulted); diags.HasError() {
+		t.Fatalf("unable to adopt bucket with provider default: %v", diags)
+	}
+}
+
+func TestResourceBucketCreateTaken(t *testing.T) {
+	svc := newReplayClient(t, "create_bucket_already_exists")
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:        "tf-replay-taken",
+		names.AttrAdoptExisting: true,
+	})
+	diags := resourceBucketCreate(context.Background(), d, svc)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, `bucket name "tf-replay-taken" is already taken by another account`) {
+		t.Fatalf("expected already taken error, got %v", diags)
+	}
+}
+
+// collidingTransport answers the first bucket creations with
+// BucketAlreadyExists before passing requests on to the next transport.
+type collidingTransport struct {
+	next       http.RoundTripper
+	collisions int
+	attempted  []string
+}
+
+func (c *collidingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPut || req.URL.Path != "/" || req.URL.RawQuery != "" {
+		return c.next.RoundTrip(req)
+	}
+
+	c.attempted = append(c.attempted, strings.Split(req.URL.Host, ".")[0])
+	if len(c.attempted) > c.collisions {
+		return c.next.RoundTrip(req)
+	}
+
+	body := "<Error><Code>BucketAlreadyExists</Code><Message>The requested bucket name is not available.</Message></Error>"
+	return &http.Response{
+		StatusCode: http.StatusConflict,
+		Header:     http.Header{"Content-Type": []string{"application/xml"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestResourceBucketPrefix(t *testing.T) {
+	ctx := context.Background()
+
+	transport := &collidingTransport{next: memory.NewBackend(), collisions: 2}
+	svc, err := NewClient(memory.Endpoint, "memory", "memory", WithTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Exactly one of bucket and bucket_prefix has to be set.
+	for _, raw := range []map[string]interface{}{
+		{},
+		{names.AttrBucket: "tf-prefixed", names.AttrBucketPrefix: "tf-prefixed-"},
+	} {
+		if diags := resourceTigrisBucket().Validate(terraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+			t.Errorf("expected validation of %v to fail", raw)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucketPrefix: "tf-prefixed-",
+	})
+	if diags := resourceBucketCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+
+	bucketName := d.Get(names.AttrBucket).(string)
+	if !strings.HasPrefix(bucketName, "tf-prefixed-") || len(bucketName) != len("tf-prefixed-")+bucketNameSuffixLength {
+		t.Errorf("generated bucket name %q does not match the prefix", bucketName)
+	}
+	if d.Id() != bucketName {
+		t.Errorf("id = %q, want %q", d.Id(), bucketName)
+	}
+	if len(transport.attempted) != 3 || transport.attempted[0] == transport.attempted[1] {
+		t.Errorf("expected a new name for each of 3 attempts, got %v", transport.attempted)
+	}
+	if err := validBucketName(bucketName); err != nil {
+		t.Error(err)
+	}
+
+	// Creation fails once every generated name is taken.
+	transport.attempted, transport.collisions = nil, bucketNameAttempts
+	d = schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucketPrefix: "tf-prefixed-",
+	})
+	diags := resourceBucketCreate(ctx, d, svc)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, `no unique bucket name with prefix "tf-prefixed-" after 5 attempts`) {
+		t.Errorf("expected no unique name error, got %v", diags)
+	}
+	if len(transport.attempted) != bucketNameAttempts {
+		t.Errorf("expected %d attempts, got %v", bucketNameAttempts, transport.attempted)
+	}
+}
+
+func TestValidBucketPrefix(t *testing.T) {
+	valid := []string{"a", "tf-", "my.bucket-", "1", strings.Repeat("a", 53)}
+	invalid := []string{"", strings.Repeat("a", 54), "My-", "bucket_", ".bucket", "my..bucket"}
+
+	for _, prefix := range valid {
+		if _, errs := validBucketPrefix(prefix, names.AttrBucketPrefix); len(errs) > 0 {
+			t.Errorf("validBucketPrefix(%q) = %v, want no errors", prefix, errs)
+		}
+		name, err := generateBucketName(prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := validBucketName(name); err != nil {
+			t.Errorf("generated name for prefix %q is invalid: %v", prefix, err)
+		}
+	}
+	for _, prefix := range invalid {
+		if _, errs := validBucketPrefix(prefix, names.AttrBucketPrefix); len(errs) == 0 {
+			t.Errorf("validBucketPrefix(%q) returned no errors", prefix)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/types"
+)
+
+func resourceTigrisBucketVersioning() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Tigris bucket versioning resource. This can be used to enable or suspend object " +
+			"versioning of a bucket. Versioning cannot be disabled once it is enabled, destroying the resource suspends it.",
+		CreateWithoutTimeout: resourceBucketVersioningCreate,
+		ReadWithoutTimeout:   resourceBucketVersioningRead,
+		UpdateWithoutTimeout: resourceBucketVersioningUpdate,
+		DeleteWithoutTimeout: resourceBucketVersioningDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			names.AttrBucket: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Tigris bucket.",
+			},
+			names.AttrStatus: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(bucketVersioningStatus_Values(), false),
+				Description:  "The versioning status of the bucket, either `Enabled` or `Suspended`.",
+			},
+		},
+	}
+}
+
+func resourceBucketVersioningCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Get(names.AttrBucket).(string)
+	status := types.BucketVersioningStatus(d.Get(names.AttrStatus).(string))
+
+	tflog.Info(ctx, "Creating bucket versioning", map[string]interface{}{
+		"bucket_name": bucketName,
+		"status":      status,
+	})
+
+	if err := svc.PutBucketVersioning(ctx, bucketName, status); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create bucket versioning, %w", err))
+	}
+
+	tflog.Info(ctx, "Bucket versioning created successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId(bucketName)
+
+	return resourceBucketVersioningRead(ctx, d, meta)
+}
+
+func resourceBucketVersioningRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	tflog.Info(ctx, "Checking bucket existence", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if !exists {
+		tflog.Warn(ctx, "Bucket not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+
+	d.Set(names.AttrBucket, bucketName)
+
+	status, err := svc.GetBucketVersioning(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket versioning, %w", err))
+	}
+	if status == "" {
+		tflog.Warn(ctx, "Bucket versioning not found, removing from state", map[string]interface{}{
+			"bucket_name": bucketName,
+		})
+
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(names.AttrStatus, string(status))
+
+	return nil
+}
+
+func resourceBucketVersioningUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+	status := types.BucketVersioningStatus(d.Get(names.AttrStatus).(string))
+
+	tflog.Info(ctx, "Updating bucket versioning", map[string]interface{}{
+		"bucket_name": bucketName,
+		"status":      status,
+	})
+
+	if err := svc.PutBucketVersioning(ctx, bucketName, status); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to update bucket versioning, %w", err))
+	}
+
+	return resourceBucketVersioningRead(ctx, d, meta)
+}
+
+func resourceBucketVersioningDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	svc := meta.(*Client)
+
+	bucketName := d.Id()
+
+	// Versioning cannot be disabled once it is enabled, only suspended.
+	tflog.Info(ctx, "Suspending bucket versioning", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	exists, err := svc.HeadBucket(ctx, bucketName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to read bucket, %w", err))
+	}
+	if exists {
+		if err := svc.PutBucketVersioning(ctx, bucketName, types.BucketVersioningStatusSuspended); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to suspend bucket versioning, %w", err))
+		}
+	}
+
+	tflog.Info(ctx, "Bucket versioning suspended successfully", map[string]interface{}{
+		"bucket_name": bucketName,
+	})
+
+	d.SetId("")
+	return nil
+}
+
+func bucketVersioningStatus_Values() []string {
+	var status types.BucketVersioningStatus
+
+	values := []string{}
+	for _, value := range status.Values() {
+		values = append(values, string(value))
+	}
+
+	return values
+}
@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tigrisdata/terraform-provider-tigris/internal/names"
+)
+
+func TestResourceBucketVersioning(t *testing.T) {
+	ctx := context.Background()
+	svc := newMemoryClient(t)
+
+	bucket := schema.TestResourceDataRaw(t, resourceTigrisBucket().Schema, map[string]interface{}{
+		names.AttrBucket:       "tf-versioned",
+		names.AttrForceDestroy: true,
+	})
+	if diags := resourceBucketCreate(ctx, bucket, svc); diags.HasError() {
+		t.Fatalf("unable to create bucket: %v", diags)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceTigrisBucketVersioning().Schema, map[string]interface{}{
+		names.AttrBucket: "tf-versioned",
+		names.AttrStatus: "Enabled",
+	})
+	if diags := resourceBucketVersioningCreate(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to create versioning: %v", diags)
+	}
+
+	// Overwritten and deleted objects keep their versions.
+	for _, content := range []string{"v1", "v2"} {
+		_, err := svc.s3Client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String("tf-versioned"),
+			Key:    aws.String("object.txt"),
+			Body:   strings.NewReader(content),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := svc.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String("tf-versioned"), Key: aws.String("object.txt")}); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := svc.s3Client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{Bucket: aws.String("tf-versioned")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions.Versions) != 2 || len(versions.DeleteMarkers) != 1 {
+		t.Fatalf("got %d versions and %d delete markers, want 2 and 1", len(versions.Versions), len(versions.DeleteMarkers))
+	}
+	if empty, err := svc.IsBucketEmpty(ctx, "tf-versioned"); err != nil || empty {
+		t.Errorf("expected bucket with only versions to be non-empty, got empty=%t err=%v", empty, err)
+	}
+
+	// Imported and suspended configurations are read back from the bucket.
+	imported := resourceTigrisBucketVersioning().Data(nil)
+	imported.SetId("tf-versioned")
+	if diags := resourceBucketVersioningDelete(ctx, d, svc); diags.HasError() {
+		t.Fatalf("unable to suspend versioning: %v", diags)
+	}
+	if diags := resourceBucketVersioningRead(ctx, imported, svc); diags.HasError() {
+		t.Fatalf("unable to read versioning: %v", diags)
+	}
+	if got := imported.Get(names.AttrStatus).(string); got != "Suspended" {
+		t.Errorf("status = %q, want %q", got, "Suspended")
+	}
+
+	// force_destroy removes all versions and delete markers.
+	if diags := resourceBucketDelete(ctx, bucket, svc); diags.HasError() {
+		t.Fatalf("unable to destroy versioned bucket: %v", diags)
+	}
+	if exists, err := svc.HeadBucket(ctx, "tf-versioned"); err != nil || exists {
+		t.Fatalf("expected bucket to be deleted, got exists=%t err=%v", exists, err)
+	}
+}
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"strings"
+	"time"
+)
+
 type BucketCannedACL string
 
 // Enum values for BucketCannedACL.
@@ -15,13 +20,163 @@ func (BucketCannedACL) Values() []BucketCannedACL {
 	}
 }
 
+type StorageClass string
+
+// Enum values for StorageClass.
+const (
+	StorageClassStandard   StorageClass = "STANDARD"
+	StorageClassStandardIA StorageClass = "STANDARD_IA"
+	StorageClassGlacier    StorageClass = "GLACIER"
+	StorageClassGlacierIR  StorageClass = "GLACIER_IR"
+)
+
+func (StorageClass) Values() []StorageClass {
+	return []StorageClass{
+		StorageClassStandard,
+		StorageClassStandardIA,
+		StorageClassGlacier,
+		StorageClassGlacierIR,
+	}
+}
+
+type ObjectRegion string
+
+// Enum values for ObjectRegion.
+const (
+	ObjectRegionAmsterdam    ObjectRegion = "ams"
+	ObjectRegionFrankfurt    ObjectRegion = "fra"
+	ObjectRegionSaoPaulo     ObjectRegion = "gru"
+	ObjectRegionHongKong     ObjectRegion = "hkg"
+	ObjectRegionAshburn      ObjectRegion = "iad"
+	ObjectRegionJohannesburg ObjectRegion = "jnb"
+	ObjectRegionLondon       ObjectRegion = "lhr"
+	ObjectRegionMadrid       ObjectRegion = "mad"
+	ObjectRegionTokyo        ObjectRegion = "nrt"
+	ObjectRegionChicago      ObjectRegion = "ord"
+	ObjectRegionSingapore    ObjectRegion = "sin"
+	ObjectRegionSanJose      ObjectRegion = "sjc"
+	ObjectRegionSydney       ObjectRegion = "syd"
+)
+
+func (ObjectRegion) Values() []ObjectRegion {
+	return []ObjectRegion{
+		ObjectRegionAmsterdam,
+		ObjectRegionFrankfurt,
+		ObjectRegionSaoPaulo,
+		ObjectRegionHongKong,
+		ObjectRegionAshburn,
+		ObjectRegionJohannesburg,
+		ObjectRegionLondon,
+		ObjectRegionMadrid,
+		ObjectRegionTokyo,
+		ObjectRegionChicago,
+		ObjectRegionSingapore,
+		ObjectRegionSanJose,
+		ObjectRegionSydney,
+	}
+}
+
+type ServerSideEncryption string
+
+// Enum values for ServerSideEncryption.
+const (
+	ServerSideEncryptionAES256 ServerSideEncryption = "AES256"
+)
+
+func (ServerSideEncryption) Values() []ServerSideEncryption {
+	return []ServerSideEncryption{
+		ServerSideEncryptionAES256,
+	}
+}
+
+type Consistency string
+
+// Enum values for Consistency.
+const (
+	ConsistencyDefault Consistency = "default"
+	ConsistencyStrict  Consistency = "strict"
+)
+
+func (Consistency) Values() []Consistency {
+	return []Consistency{
+		ConsistencyDefault,
+		ConsistencyStrict,
+	}
+}
+
+type NotificationEvent string
+
+// Enum values for NotificationEvent.
+const (
+	NotificationEventObjectCreated NotificationEvent = "OBJECT_CREATED_PUT"
+	NotificationEventObjectDeleted NotificationEvent = "OBJECT_DELETED"
+)
+
+func (NotificationEvent) Values() []NotificationEvent {
+	return []NotificationEvent{
+		NotificationEventObjectCreated,
+		NotificationEventObjectDeleted,
+	}
+}
+
+type AccessKeyStatus string
+
+// Enum values for AccessKeyStatus.
+const (
+	AccessKeyStatusActive   AccessKeyStatus = "Active"
+	AccessKeyStatusInactive AccessKeyStatus = "Inactive"
+)
+
+func (AccessKeyStatus) Values() []AccessKeyStatus {
+	return []AccessKeyStatus{
+		AccessKeyStatusActive,
+		AccessKeyStatusInactive,
+	}
+}
+
+type BucketVersioningStatus string
+
+// Enum values for BucketVersioningStatus.
+const (
+	BucketVersioningStatusEnabled   BucketVersioningStatus = "Enabled"
+	BucketVersioningStatusSuspended BucketVersioningStatus = "Suspended"
+)
+
+func (BucketVersioningStatus) Values() []BucketVersioningStatus {
+	return []BucketVersioningStatus{
+		BucketVersioningStatusEnabled,
+		BucketVersioningStatusSuspended,
+	}
+}
+
+type ObjectLockRetentionMode string
+
+// Enum values for ObjectLockRetentionMode.
+const (
+	ObjectLockRetentionModeGovernance ObjectLockRetentionMode = "GOVERNANCE"
+	ObjectLockRetentionModeCompliance ObjectLockRetentionMode = "COMPLIANCE"
+)
+
+func (ObjectLockRetentionMode) Values() []ObjectLockRetentionMode {
+	return []ObjectLockRetentionMode{
+		ObjectLockRetentionModeGovernance,
+		ObjectLockRetentionModeCompliance,
+	}
+}
+
 type BucketMetadata struct {
 	Name          string               `json:"name"`
 	CacheControl  string               `json:"cache_control"`
 	ObjectRegions string               `json:"object_regions"`
+	StorageClass  StorageClass         `json:"storage_class"`
+	Consistency   Consistency          `json:"consistency"`
+	Snapshot      bool                 `json:"snapshot_enabled"`
+	ForkSource    *BucketForkSource    `json:"fork_source"`
 	MD            *BucketMD            `json:"md"`
 	Shadow        *BucketShadowConfig  `json:"shadow_bucket"`
 	Website       *BucketWebsiteConfig `json:"website"`
+
+	ObjectNotifications *BucketNotificationConfig `json:"object_notifications"`
 }
 
 type BucketMD struct {
@@ -49,10 +204,71 @@ func (b *BucketMetadata) GetPublicObjectsListEnabled() bool {
 	return false
 }
 
+// GetStorageClass returns the default storage class of the bucket.
+func (b *BucketMetadata) GetStorageClass() StorageClass {
+	if b.StorageClass == "" {
+		return StorageClassStandard
+	}
+
+	return b.StorageClass
+}
+
+// GetConsistency returns the consistency mode of the bucket.
+func (b *BucketMetadata) GetConsistency() Consistency {
+	if b.Consistency == "" {
+		return ConsistencyDefault
+	}
+
+	return b.Consistency
+}
+
+// GetObjectRegions returns the regions objects are restricted to. An empty
+// list means objects are placed globally.
+func (b *BucketMetadata) GetObjectRegions() []string {
+	regions := []string{}
+	for _, region := range strings.Split(b.ObjectRegions, ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			regions = append(regions, region)
+		}
+	}
+
+	return regions
+}
+
+// BucketForkSource is the bucket, and optionally the snapshot, a bucket was
+// forked from.
+type BucketForkSource struct {
+	Bucket   string `json:"bucket"`
+	Snapshot string `json:"snapshot"`
+}
+
 type BucketWebsiteConfig struct {
 	DomainName string `json:"domain_name"`
 }
 
+// BucketNotificationConfig is the object notification configuration of a
+// bucket. The auth secrets are never returned by the metadata API.
+type BucketNotificationConfig struct {
+	// The URL object events are POSTed to.
+	WebhookURL string `json:"web_hook"`
+
+	// The object events to notify about. All events are sent when empty.
+	Events []NotificationEvent `json:"events,omitempty"`
+
+	// The key prefix of the objects to notify about.
+	Prefix string `json:"prefix,omitempty"`
+
+	// The credentials sent to the webhook.
+	Auth *BucketNotificationAuth `json:"auth,omitempty"`
+}
+
+// BucketNotificationAuth is the token or basic auth sent to a webhook.
+type BucketNotificationAuth struct {
+	Token         string `json:"token,omitempty"`
+	BasicUsername string `json:"basic_user,omitempty"`
+	BasicPassword string `json:"basic_pass,omitempty"`
+}
+
 type BucketShadowConfig struct {
 	AccessKey    string `json:"access_key"`
 	SecretKey    string `json:"secret_key"`
@@ -78,12 +294,180 @@ type BucketUpdateInput struct {
 
 	// The shadow bucket configuration for the bucket.
 	Shadow *BucketShadowConfig
+
+	// The object notification configuration for the bucket. An empty
+	// configuration removes the notifications.
+	ObjectNotifications *BucketNotificationConfig
+
+	// The comma separated list of regions to restrict objects to. An empty
+	// string removes the restriction.
+	ObjectRegions *string
+
+	// The default Cache-Control header for objects in the bucket. An empty
+	// string removes the default.
+	CacheControl *string
+
+	// The default storage class for objects in the bucket.
+	StorageClass *StorageClass
+
+	// The consistency mode of the bucket.
+	Consistency *Consistency
+
+	// Whether to enable snapshots for the bucket. It can only be set when
+	// the bucket is created.
+	EnableSnapshot *bool
+
+	// Whether to enable object lock for the bucket. It can only be set when
+	// the bucket is created.
+	ObjectLockEnabled *bool
+
+	// The bucket to fork the new bucket from. It can only be set when the
+	// bucket is created.
+	ForkSourceBucket *string
+
+	// The snapshot version of the fork source bucket to fork from. The
+	// current state of the source bucket is forked when it is not set.
+	ForkSourceSnapshot *string
+}
+
+// BucketSnapshot is a point-in-time snapshot of a bucket.
+type BucketSnapshot struct {
+	// The bucket the snapshot was taken of.
+	Bucket string
+
+	// The optional name of the snapshot.
+	Name string
+
+	// The version that identifies the snapshot.
+	Version string
+
+	// The time the snapshot was taken.
+	CreationDate time.Time
+}
+
+// BucketEncryption is the default server-side encryption of a bucket.
+type BucketEncryption struct {
+	// The server-side encryption algorithm applied to new objects.
+	Algorithm ServerSideEncryption
+}
+
+// AccessKey is a Tigris access key. The secret is only returned when the key
+// is created.
+type AccessKey struct {
+	Name            string
+	AccessKeyID     string
+	SecretAccessKey string
+	Status          AccessKeyStatus
+	CreateDate      time.Time
+}
+
+// BucketLogging is the server access logging configuration of a bucket.
+type BucketLogging struct {
+	// The bucket the access logs are delivered to.
+	TargetBucket string
+
+	// The prefix of the access log objects in the target bucket.
+	TargetPrefix string
+}
+
+// CORSRule is a cross-origin resource sharing rule of a bucket.
+type CORSRule struct {
+	// The HTTP methods the origins are allowed to use.
+	AllowedMethods []string
+
+	// The origins allowed to make cross-origin requests.
+	AllowedOrigins []string
+
+	// The headers allowed in preflight requests.
+	AllowedHeaders []string
+
+	// The response headers the browser is allowed to access.
+	ExposeHeaders []string
+
+	// How long the browser can cache the preflight response, in seconds.
+	MaxAgeSeconds int32
+}
+
+// LifecycleRule is a lifecycle rule of a bucket. The rule applies to the
+// objects matching both the prefix and all of the tags.
+type LifecycleRule struct {
+	// The unique identifier of the rule.
+	ID string
+
+	// Whether the rule is applied.
+	Enabled bool
+
+	// The key prefix of the objects the rule applies to.
+	Prefix string
+
+	// The tags of the objects the rule applies to.
+	Tags map[string]string
+
+	// When the current versions of the objects expire.
+	Expiration *LifecycleExpiration
+
+	// When the objects move to another storage class.
+	Transitions []LifecycleTransition
+
+	// When the noncurrent versions of the objects expire.
+	NoncurrentVersionExpiration *NoncurrentVersionExpiration
+
+	// The number of days after which incomplete multipart uploads are
+	// aborted, or zero to keep them.
+	AbortIncompleteMultipartUploadDays int32
+}
+
+// LifecycleExpiration expires objects after a number of days or at a date.
+type LifecycleExpiration struct {
+	Days                      int32
+	Date                      *time.Time
+	ExpiredObjectDeleteMarker bool
+}
+
+// LifecycleTransition moves objects to a storage class after a number of
+// days or at a date.
+type LifecycleTransition struct {
+	Days         int32
+	Date         *time.Time
+	StorageClass StorageClass
+}
+
+// NoncurrentVersionExpiration expires noncurrent object versions a number of
+// days after they become noncurrent, keeping the newest versions.
+type NoncurrentVersionExpiration struct {
+	NoncurrentDays          int32
+	NewerNoncurrentVersions int32
+}
+
+// BucketObjectLock is the object lock configuration of a bucket.
+type BucketObjectLock struct {
+	// Whether object lock is enabled. It can only be enabled when the bucket
+	// is created and cannot be disabled.
+	Enabled bool
+
+	// The default retention applied to new objects, nil when there is none.
+	DefaultRetention *ObjectLockRetention
+}
+
+// ObjectLockRetention is the default retention of new objects in a bucket
+// with object lock enabled. Exactly one of Days and Years is set.
+type ObjectLockRetention struct {
+	Mode  ObjectLockRetentionMode
+	Days  int32
+	Years int32
 }
 
 // BucketUpdateRequest is the request body for the UpdateBucket API.
 type BucketUpdateRequest struct {
 	Website *BucketWebsiteConfig `json:"website"`
 	Shadow  *BucketShadowConfig  `json:"shadow_bucket"`
+
+	ObjectNotifications *BucketNotificationConfig `json:"object_notifications,omitempty"`
+
+	ObjectRegions *string       `json:"object_regions,omitempty"`
+	CacheControl  *string       `json:"cache_control,omitempty"`
+	StorageClass  *StorageClass `json:"storage_class,omitempty"`
+	Consistency   *Consistency  `json:"consistency,omitempty"`
 }
 
 type BucketUpdateResponse struct {
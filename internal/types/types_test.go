@@ -0,0 +1,70 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBucketMetadataHelpers(t *testing.T) {
+	tests := []struct {
+		body           string
+		wantACL        BucketCannedACL
+		wantPublicList bool
+	}{
+		{body: `{}`, wantACL: BucketCannedACLPrivate, wantPublicList: true},
+		{body: `{"md":null}`, wantACL: BucketCannedACLPrivate, wantPublicList: true},
+		{body: `{"md":{"X-Amz-Acl":"public-read"}}`, wantACL: BucketCannedACLPublicRead, wantPublicList: true},
+		{body: `{"md":{"x-amz-acl-public-list-objects-enabled":"false"}}`, wantACL: BucketCannedACLPrivate, wantPublicList: false},
+		{body: `{"md":{"x-amz-acl-public-list-objects-enabled":"TRUE"}}`, wantACL: BucketCannedACLPrivate, wantPublicList: false},
+	}
+
+	for _, tt := range tests {
+		var metadata BucketMetadata
+		if err := json.Unmarshal([]byte(tt.body), &metadata); err != nil {
+			t.Fatalf("unable to decode %s: %v", tt.body, err)
+		}
+		if acl := metadata.GetBucketCannedACL(); acl != tt.wantACL {
+			t.Errorf("%s: acl = %q, want %q", tt.body, acl, tt.wantACL)
+		}
+		if got := metadata.GetPublicObjectsListEnabled(); got != tt.wantPublicList {
+			t.Errorf("%s: public list = %t, want %t", tt.body, got, tt.wantPublicList)
+		}
+	}
+}
+
+func FuzzBucketMetadataDecode(f *testing.F) {
+	for _, seed := range []string{
+		`{}`,
+		`null`,
+		`{"name":"bucket","md":{"X-Amz-Acl":"public-read","x-amz-acl-public-list-objects-enabled":"true"}}`,
+		`{"md":{"X-Amz-Acl":null,"x-amz-acl-public-list-objects-enabled":null}}`,
+		`{"md":{"x-amz-acl":"private","X-AMZ-ACL-PUBLIC-LIST-OBJECTS-ENABLED":"false"}}`,
+		`{"shadow_bucket":{"write_through":true},"website":{"domain_name":"example.com"}}`,
+		`{"md":[]}`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var metadata BucketMetadata
+		if err := json.Unmarshal(data, &metadata); err != nil {
+			return
+		}
+
+		acl := metadata.GetBucketCannedACL()
+		if metadata.MD == nil || metadata.MD.ACL == nil {
+			if acl != BucketCannedACLPrivate {
+				t.Fatalf("acl = %q without metadata, want %q", acl, BucketCannedACLPrivate)
+			}
+		}
+
+		enabled := metadata.GetPublicObjectsListEnabled()
+		if metadata.MD != nil && metadata.MD.PublicObjectsListEnabled != nil {
+			if want := *metadata.MD.PublicObjectsListEnabled == "true"; enabled != want {
+				t.Fatalf("public list = %t for %q", enabled, *metadata.MD.PublicObjectsListEnabled)
+			}
+		} else if !enabled {
+			t.Fatal("public list should default to enabled")
+		}
+	})
+}